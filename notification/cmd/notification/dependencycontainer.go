@@ -1,18 +1,27 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
 
-// TODO: добавить зависимости
+	appservice "notification/pkg/application/service"
+	"notification/pkg/infrastructure/integrationevent"
+	inframysql "notification/pkg/infrastructure/mysql"
+)
 
 func newDependencyContainer(
 	_ *config,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	libUoW := mysql.NewUnitOfWork(connContainer.connectionPool, inframysql.NewRepositoryProvider)
+	uow := inframysql.NewUnitOfWork(libUoW)
+	eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		notificationService: appservice.NewNotificationService(uow, eventDispatcher),
 	}, nil
 }
 
 type dependencyContainer struct {
-	db *sqlx.DB
+	notificationService appservice.NotificationService
 }
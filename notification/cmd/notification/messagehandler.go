@@ -0,0 +1,101 @@
+package main
+
+import (
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"notification/pkg/infrastructure/integrationevent"
+)
+
+func messageHandler(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "message-handler",
+		Usage: "Publishes stored domain events to AMQP and handles events of other services",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			container, err := newDependencyContainer(config, connContainer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init dependencies")
+			}
+
+			libLogger := newLibLogger(logger)
+			amqpConnection := amqp.NewAMQPConnection(appID, &amqp.ConnectionConfig{
+				User:           config.AMQPUser,
+				Password:       config.AMQPPassword,
+				Host:           config.AMQPHost,
+				ConnectTimeout: config.AMQPConnectTimeout,
+			}, libLogger)
+			amqpEventProducer := amqpConnection.Producer(
+				&amqp.ExchangeConfig{
+					Name:    integrationevent.ExchangeName,
+					Kind:    integrationevent.ExchangeKind,
+					Durable: true,
+				},
+				nil,
+				nil,
+			)
+			amqpTransport := integrationevent.NewAMQPTransport(libLogger, container.notificationService)
+			amqpConnection.Consumer(
+				c.Context,
+				amqpTransport.Handler(),
+				&amqp.QueueConfig{
+					Name:    integrationevent.UserEventsQueueName,
+					Durable: true,
+				},
+				&amqp.BindConfig{
+					QueueName:    integrationevent.UserEventsQueueName,
+					ExchangeName: integrationevent.ExchangeName,
+					RoutingKeys:  []string{integrationevent.UserRoutingKeyPrefix + "UserCreated"},
+				},
+				&amqp.QoSConfig{
+					PrefetchCount: 100,
+				},
+			)
+			// order events share a queue, so OrderCreated is handled before status changes of the order
+			amqpConnection.Consumer(
+				c.Context,
+				amqpTransport.Handler(),
+				&amqp.QueueConfig{
+					Name:    integrationevent.OrderEventsQueueName,
+					Durable: true,
+				},
+				&amqp.BindConfig{
+					QueueName:    integrationevent.OrderEventsQueueName,
+					ExchangeName: integrationevent.ExchangeName,
+					RoutingKeys: []string{
+						integrationevent.OrderRoutingKeyPrefix + "OrderCreated",
+						integrationevent.OrderRoutingKeyPrefix + "OrderStatusChanged",
+					},
+				},
+				&amqp.QoSConfig{
+					PrefetchCount: 100,
+				},
+			)
+			err = amqpConnection.Start()
+			if err != nil {
+				return errors.Wrap(err, "failed to start AMQP connection")
+			}
+			closer.Add(libio.CloserFunc(amqpConnection.Stop))
+
+			outboxEventHandler := outbox.NewEventHandler(outbox.EventHandlerConfig{
+				TransportName:  integrationevent.TransportName,
+				Transport:      integrationevent.NewOutboxTransport(libLogger, amqpEventProducer),
+				ConnectionPool: connContainer.connectionPool,
+				Logger:         libLogger,
+			})
+			return outboxEventHandler.Start(c.Context)
+		},
+	}
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS recipient
+(
+    `user_id`     VARCHAR(64)  NOT NULL,
+    `email`       VARCHAR(255) NOT NULL,
+    `telegram_id` VARCHAR(255) NOT NULL,
+    `created_at`  DATETIME     NOT NULL,
+    `updated_at`  DATETIME     NOT NULL,
+    PRIMARY KEY (`user_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
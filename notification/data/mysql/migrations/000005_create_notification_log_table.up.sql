@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notification_log
+(
+    `notification_id` VARCHAR(64) NOT NULL,
+    `user_id`         VARCHAR(64) NOT NULL,
+    `channel`         VARCHAR(32) NOT NULL,
+    `message`         TEXT        NOT NULL,
+    `sent_at`         DATETIME    NOT NULL,
+    PRIMARY KEY (`notification_id`),
+    INDEX `user_id_idx` (`user_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS order_customer
+(
+    `order_id`    VARCHAR(64) NOT NULL,
+    `customer_id` VARCHAR(64) NOT NULL,
+    PRIMARY KEY (`order_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+
+	"notification/pkg/domain/service"
+)
+
+type domainEventDispatcher struct {
+	ctx             context.Context
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (d *domainEventDispatcher) Dispatch(event service.Event) error {
+	return d.eventDispatcher.Dispatch(d.ctx, event)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+
+	"notification/pkg/domain/model"
+	"notification/pkg/domain/service"
+)
+
+type NotificationService interface {
+	HandleUserCreated(ctx context.Context, event model.UserCreatedEvent) error
+	HandleOrderCreated(ctx context.Context, event model.OrderCreatedEvent) error
+	HandleOrderStatusChanged(ctx context.Context, event model.OrderStatusChangedEvent) error
+}
+
+func NewNotificationService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) NotificationService {
+	return &notificationService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type notificationService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *notificationService) HandleUserCreated(ctx context.Context, event model.UserCreatedEvent) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).HandleUserCreated(event)
+	})
+}
+
+func (s *notificationService) HandleOrderCreated(ctx context.Context, event model.OrderCreatedEvent) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).HandleOrderCreated(event)
+	})
+}
+
+func (s *notificationService) HandleOrderStatusChanged(ctx context.Context, event model.OrderStatusChangedEvent) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).HandleOrderStatusChanged(event)
+	})
+}
+
+func (s *notificationService) domainService(ctx context.Context, provider RepositoryProvider) service.Notification {
+	return service.NewNotificationService(provider.NotificationRepository(ctx), s.domainEventDispatcher(ctx))
+}
+
+func (s *notificationService) domainEventDispatcher(ctx context.Context) service.EventDispatcher {
+	return &domainEventDispatcher{
+		ctx:             ctx,
+		eventDispatcher: s.eventDispatcher,
+	}
+}
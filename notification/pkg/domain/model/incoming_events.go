@@ -8,8 +8,14 @@ type UserCreatedEvent struct {
 	TelegramID string
 }
 
+// OrderCreatedEvent tells which customer the order belongs to, status events of the order do not carry it
+type OrderCreatedEvent struct {
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+}
+
 type OrderStatusChangedEvent struct {
-	OrderID   uuid.UUID
-	UserID    uuid.UUID
+	OrderID uuid.UUID
+	// NewStatus is a name of the order status, e.g. Paid
 	NewStatus string
 }
@@ -9,6 +9,7 @@ import (
 
 var (
 	ErrRecipientNotFound = errors.New("recipient not found")
+	ErrOrderNotFound     = errors.New("order not found")
 )
 
 type ChannelType string
@@ -38,4 +39,7 @@ type NotificationRepository interface {
 	StoreRecipient(recipient *Recipient) error
 	FindRecipientByUserID(userID uuid.UUID) (*Recipient, error)
 	StoreLog(log *NotificationLog) error
+	// StoreOrderCustomer remembers customer of the order, storing the same order again does nothing
+	StoreOrderCustomer(orderID, customerID uuid.UUID) error
+	FindOrderCustomer(orderID uuid.UUID) (uuid.UUID, error)
 }
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"notification/pkg/domain/model"
 
@@ -18,6 +20,7 @@ type EventDispatcher interface {
 
 type Notification interface {
 	HandleUserCreated(event model.UserCreatedEvent) error
+	HandleOrderCreated(event model.OrderCreatedEvent) error
 	HandleOrderStatusChanged(event model.OrderStatusChangedEvent) error
 }
 
@@ -34,18 +37,29 @@ type notificationService struct {
 }
 
 func (s *notificationService) HandleUserCreated(event model.UserCreatedEvent) error {
+	now := time.Now()
 	recipient := &model.Recipient{
 		UserID:     event.UserID,
 		Email:      event.Email,
 		TelegramID: event.TelegramID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 	return s.repo.StoreRecipient(recipient)
 }
 
+func (s *notificationService) HandleOrderCreated(event model.OrderCreatedEvent) error {
+	return s.repo.StoreOrderCustomer(event.OrderID, event.CustomerID)
+}
+
 func (s *notificationService) HandleOrderStatusChanged(event model.OrderStatusChangedEvent) error {
-	recipient, err := s.repo.FindRecipientByUserID(event.UserID)
+	customerID, err := s.repo.FindOrderCustomer(event.OrderID)
+	if err != nil {
+		return err
+	}
+	recipient, err := s.repo.FindRecipientByUserID(customerID)
 	if err != nil {
-		fmt.Printf("error: recipient not found for user %s, cannot send notification\n", event.UserID)
+		fmt.Printf("error: recipient not found for user %s, cannot send notification\n", customerID)
 		return err
 	}
 
@@ -56,19 +70,23 @@ func (s *notificationService) HandleOrderStatusChanged(event model.OrderStatusCh
 		UserID:  recipient.UserID,
 		Channel: model.ChannelEmail,
 		Message: message,
+		SentAt:  time.Now(),
 	}
 	if err := s.repo.StoreLog(logEntry); err != nil {
-		_ = s.dispatcher.Dispatch(model.NotificationFailed{
+		dispatchErr := s.dispatcher.Dispatch(model.NotificationFailed{
 			UserID: recipient.UserID, Channel: model.ChannelEmail, Reason: "failed to store log",
 		})
-		return fmt.Errorf("failed to store notification log: %w", err)
+		return errors.Join(fmt.Errorf("failed to store notification log: %w", err), dispatchErr)
 	}
 
-	_ = s.dispatcher.Dispatch(model.NotificationSent{
+	err = s.dispatcher.Dispatch(model.NotificationSent{
 		NotificationID: logEntry.ID,
 		UserID:         recipient.UserID,
 		Channel:        model.ChannelEmail,
 	})
+	if err != nil {
+		return err
+	}
 
 	fmt.Printf("Sent notification to %s: %s\n", recipient.Email, message)
 	return nil
@@ -49,9 +49,14 @@ func TestNotificationService(t *testing.T) {
 			Email:  "test@test.ru",
 		})
 
+		orderID := uuid.New()
+		require.NoError(t, svc.HandleOrderCreated(model.OrderCreatedEvent{
+			OrderID:    orderID,
+			CustomerID: userID,
+		}))
+
 		orderStatusEvent := model.OrderStatusChangedEvent{
-			OrderID:   uuid.New(),
-			UserID:    userID,
+			OrderID:   orderID,
 			NewStatus: "Paid",
 		}
 
@@ -75,9 +80,14 @@ func TestNotificationService(t *testing.T) {
 		dispatcher := &mockEventDispatcher{}
 		svc := service.NewNotificationService(repo, dispatcher)
 
+		orderID := uuid.New()
+		require.NoError(t, svc.HandleOrderCreated(model.OrderCreatedEvent{
+			OrderID:    orderID,
+			CustomerID: uuid.New(),
+		}))
+
 		orderStatusEvent := model.OrderStatusChangedEvent{
-			OrderID:   uuid.New(),
-			UserID:    uuid.New(),
+			OrderID:   orderID,
 			NewStatus: "Pending",
 		}
 
@@ -88,6 +98,39 @@ func TestNotificationService(t *testing.T) {
 		require.Empty(t, repo.logs)
 		require.Empty(t, dispatcher.events)
 	})
+
+	t.Run("HandleOrderStatusChanged_FailsForUnknownOrder", func(t *testing.T) {
+		repo := &mockNotificationRepository{
+			recipients: make(map[uuid.UUID]*model.Recipient),
+			logs:       make([]*model.NotificationLog, 0),
+		}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewNotificationService(repo, dispatcher)
+
+		err := svc.HandleOrderStatusChanged(model.OrderStatusChangedEvent{
+			OrderID:   uuid.New(),
+			NewStatus: "Paid",
+		})
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.Empty(t, dispatcher.events)
+	})
+
+	t.Run("HandleOrderCreated_KeepsFirstCustomerOnRedelivery", func(t *testing.T) {
+		repo := &mockNotificationRepository{
+			recipients: make(map[uuid.UUID]*model.Recipient),
+			logs:       make([]*model.NotificationLog, 0),
+		}
+		svc := service.NewNotificationService(repo, &mockEventDispatcher{})
+
+		orderID := uuid.New()
+		customerID := uuid.New()
+		require.NoError(t, svc.HandleOrderCreated(model.OrderCreatedEvent{OrderID: orderID, CustomerID: customerID}))
+		require.NoError(t, svc.HandleOrderCreated(model.OrderCreatedEvent{OrderID: orderID, CustomerID: uuid.New()}))
+
+		stored, err := repo.FindOrderCustomer(orderID)
+		require.NoError(t, err)
+		require.Equal(t, customerID, stored)
+	})
 }
 
 var _ model.NotificationRepository = (*mockNotificationRepository)(nil)
@@ -95,6 +138,7 @@ var _ model.NotificationRepository = (*mockNotificationRepository)(nil)
 type mockNotificationRepository struct {
 	recipients map[uuid.UUID]*model.Recipient
 	logs       []*model.NotificationLog
+	orders     map[uuid.UUID]uuid.UUID
 }
 
 func (m *mockNotificationRepository) StoreRecipient(r *model.Recipient) error {
@@ -120,6 +164,23 @@ func (m *mockNotificationRepository) StoreLog(log *model.NotificationLog) error
 	return nil
 }
 
+func (m *mockNotificationRepository) StoreOrderCustomer(orderID, customerID uuid.UUID) error {
+	if m.orders == nil {
+		m.orders = make(map[uuid.UUID]uuid.UUID)
+	}
+	if _, ok := m.orders[orderID]; !ok {
+		m.orders[orderID] = customerID
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) FindOrderCustomer(orderID uuid.UUID) (uuid.UUID, error) {
+	if customerID, ok := m.orders[orderID]; ok {
+		return customerID, nil
+	}
+	return uuid.Nil, model.ErrOrderNotFound
+}
+
 var _ service.EventDispatcher = (*mockEventDispatcher)(nil)
 
 type mockEventDispatcher struct {
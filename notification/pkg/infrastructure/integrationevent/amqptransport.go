@@ -0,0 +1,177 @@
+package integrationevent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"github.com/google/uuid"
+
+	"notification/pkg/application/service"
+	"notification/pkg/domain/model"
+)
+
+const (
+	// OrderEventsQueueName collects events of order service consumed by notification service
+	OrderEventsQueueName  = "notification_order_events"
+	OrderRoutingKeyPrefix = "order."
+	// UserEventsQueueName collects events of user service consumed by notification service
+	UserEventsQueueName  = "notification_user_events"
+	UserRoutingKeyPrefix = "user."
+)
+
+// orderEventVersion is a version of order event payloads mirrored below
+const orderEventVersion = 1
+
+// orderStatusNames mirrors order statuses of order service
+var orderStatusNames = map[int]string{
+	0: "Open",
+	1: "Pending",
+	2: "Paid",
+	3: "Cancelled",
+}
+
+var errUnhandledDelivery = errors.New("unhandled delivery")
+
+func NewAMQPTransport(logger logging.Logger, notificationService service.NotificationService) AMQPTransport {
+	return &amqpTransport{
+		logger:              logger,
+		notificationService: notificationService,
+	}
+}
+
+type AMQPTransport interface {
+	Handler() amqp.Handler
+}
+
+type amqpTransport struct {
+	logger              logging.Logger
+	notificationService service.NotificationService
+}
+
+func (t *amqpTransport) Handler() amqp.Handler {
+	return t.withLog(t.handle)
+}
+
+func (t *amqpTransport) handle(ctx context.Context, delivery amqp.Delivery) error {
+	switch delivery.Type {
+	case "UserCreated":
+		var e UserCreated
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		return t.notificationService.HandleUserCreated(ctx, model.UserCreatedEvent{
+			UserID:     e.UserID,
+			Email:      valueOrEmpty(e.Email),
+			TelegramID: valueOrEmpty(e.Telegram),
+		})
+	case "OrderCreated":
+		var e OrderCreated
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		if err = checkOrderEventVersion(e.Version); err != nil {
+			return err
+		}
+		return t.notificationService.HandleOrderCreated(ctx, model.OrderCreatedEvent{
+			OrderID:    e.OrderID,
+			CustomerID: e.CustomerID,
+		})
+	case "OrderStatusChanged":
+		var e OrderStatusChanged
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		if err = checkOrderEventVersion(e.Version); err != nil {
+			return err
+		}
+		status, ok := orderStatusNames[e.NewStatus]
+		if !ok {
+			return fmt.Errorf("unknown order status %d", e.NewStatus)
+		}
+		return t.notificationService.HandleOrderStatusChanged(ctx, model.OrderStatusChangedEvent{
+			OrderID:   e.OrderID,
+			NewStatus: status,
+		})
+	default:
+		return errUnhandledDelivery
+	}
+}
+
+func (t *amqpTransport) withLog(handler amqp.Handler) amqp.Handler {
+	return func(ctx context.Context, delivery amqp.Delivery) error {
+		l := t.logger.WithFields(logging.Fields{
+			"routing_key":    delivery.RoutingKey,
+			"correlation_id": delivery.CorrelationID,
+			"content_type":   delivery.ContentType,
+		})
+		if delivery.ContentType != ContentType {
+			l.Warning(errors.New("invalid content type"), "skipping")
+			return nil
+		}
+		l = l.WithField("body", json.RawMessage(delivery.Body))
+
+		start := time.Now()
+		err := handler(ctx, delivery)
+		l = l.WithField("duration", time.Since(start))
+
+		if err != nil {
+			if errors.Is(err, errUnhandledDelivery) {
+				l.Info("unhandled delivery, skipping")
+				return nil
+			}
+			// requeueing will not make the order or the recipient appear
+			if errors.Is(err, model.ErrOrderNotFound) || errors.Is(err, model.ErrRecipientNotFound) {
+				l.Warning(err, "nobody to notify, skipping")
+				return nil
+			}
+			l.Error(err, "failed to handle message")
+		} else {
+			l.Info("successfully handled message")
+		}
+		return err
+	}
+}
+
+func checkOrderEventVersion(version int) error {
+	if version != orderEventVersion {
+		return fmt.Errorf("unsupported order event version %d", version)
+	}
+	return nil
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// UserCreated mirrors event published by user service
+type UserCreated struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    *string   `json:"email,omitempty"`
+	Telegram *string   `json:"telegram,omitempty"`
+}
+
+// OrderCreated mirrors event published by order service
+type OrderCreated struct {
+	Version    int       `json:"version"`
+	OrderID    uuid.UUID `json:"order_id"`
+	CustomerID uuid.UUID `json:"customer_id"`
+}
+
+// OrderStatusChanged mirrors event published by order service
+type OrderStatusChanged struct {
+	Version   int       `json:"version"`
+	OrderID   uuid.UUID `json:"order_id"`
+	OldStatus int       `json:"old_status"`
+	NewStatus int       `json:"new_status"`
+}
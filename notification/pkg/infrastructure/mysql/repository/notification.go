@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"notification/pkg/domain/model"
+)
+
+func NewNotificationRepository(ctx context.Context, client mysql.ClientContext) model.NotificationRepository {
+	return &notificationRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type notificationRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (n *notificationRepository) StoreRecipient(recipient *model.Recipient) error {
+	_, err := n.client.ExecContext(n.ctx,
+		`
+	INSERT INTO recipient (user_id, email, telegram_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		email=VALUES(email),
+		telegram_id=VALUES(telegram_id),
+		updated_at=VALUES(updated_at)
+	`,
+		recipient.UserID,
+		recipient.Email,
+		recipient.TelegramID,
+		recipient.CreatedAt,
+		recipient.UpdatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (n *notificationRepository) FindRecipientByUserID(userID uuid.UUID) (*model.Recipient, error) {
+	var recipient sqlxRecipient
+	err := n.client.GetContext(
+		n.ctx,
+		&recipient,
+		`SELECT user_id, email, telegram_id, created_at, updated_at FROM recipient WHERE user_id = ?`,
+		userID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrRecipientNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Recipient{
+		UserID:     recipient.UserID,
+		Email:      recipient.Email,
+		TelegramID: recipient.TelegramID,
+		CreatedAt:  recipient.CreatedAt,
+		UpdatedAt:  recipient.UpdatedAt,
+	}, nil
+}
+
+func (n *notificationRepository) StoreLog(log *model.NotificationLog) error {
+	_, err := n.client.ExecContext(n.ctx,
+		`INSERT INTO notification_log (notification_id, user_id, channel, message, sent_at) VALUES (?, ?, ?, ?, ?)`,
+		log.ID,
+		log.UserID,
+		log.Channel,
+		log.Message,
+		log.SentAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (n *notificationRepository) StoreOrderCustomer(orderID, customerID uuid.UUID) error {
+	_, err := n.client.ExecContext(n.ctx,
+		`INSERT IGNORE INTO order_customer (order_id, customer_id) VALUES (?, ?)`,
+		orderID,
+		customerID,
+	)
+	return errors.WithStack(err)
+}
+
+func (n *notificationRepository) FindOrderCustomer(orderID uuid.UUID) (uuid.UUID, error) {
+	var customerID uuid.UUID
+	err := n.client.GetContext(
+		n.ctx,
+		&customerID,
+		`SELECT customer_id FROM order_customer WHERE order_id = ?`,
+		orderID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errors.WithStack(model.ErrOrderNotFound)
+		}
+		return uuid.Nil, errors.WithStack(err)
+	}
+	return customerID, nil
+}
+
+type sqlxRecipient struct {
+	UserID     uuid.UUID `db:"user_id"`
+	Email      string    `db:"email"`
+	TelegramID string    `db:"telegram_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
@@ -28,7 +28,22 @@ type config struct {
 	DBPassword string `envconfig:"db_password"`
 	DBMaxConn  int    `envconfig:"db_max_conn"`
 
-	TestGRPCAddress string `envconfig:"test_grpc_address" default:"test:8081"`
+	AMQPHost           string        `envconfig:"amqp_host" default:"localhost:5672"`
+	AMQPUser           string        `envconfig:"amqp_user"`
+	AMQPPassword       string        `envconfig:"amqp_password"`
+	AMQPConnectTimeout time.Duration `envconfig:"amqp_connect_timeout" default:"30s"`
+
+	TestGRPCAddress    string `envconfig:"test_grpc_address" default:"test:8081"`
+	ProductGRPCAddress string `envconfig:"product_grpc_address" default:"product:8081"`
+	PaymentGRPCAddress string `envconfig:"payment_grpc_address" default:"payment:8081"`
+
+	TemporalHost string `envconfig:"temporal_host" default:"localhost:7233"`
+	// PendingOrderTTL is how long order waits for payment before it is cancelled
+	PendingOrderTTL time.Duration `envconfig:"pending_order_ttl" default:"30m"`
+
+	IdempotencyKeyTTL time.Duration `envconfig:"idempotency_key_ttl" default:"24h"`
+	// IdempotencyKeyLease is how long call may stay in progress before its key can be claimed by a retry
+	IdempotencyKeyLease time.Duration `envconfig:"idempotency_key_lease" default:"1m"`
 }
 
 func (c *config) buildDSN() string {
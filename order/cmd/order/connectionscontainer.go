@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"io"
 
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"order/pkg/infrastructure/temporal"
 )
 
 type multiCloser struct {
@@ -37,7 +42,7 @@ func (m *multiCloser) Close() error {
 
 func newConnectionsContainer(
 	config *config,
-	_ *log.Logger,
+	logger *log.Logger,
 	multiCloser *multiCloser,
 ) (container *connectionsContainer, err error) {
 	containerBuilder := func() error {
@@ -47,7 +52,6 @@ func newConnectionsContainer(
 		if err != nil {
 			return fmt.Errorf("failed to init DB for migrations: %w", err)
 		}
-		defer db.Close()
 
 		if err = applyMigrations(db.DB, pathToMigrations); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
@@ -55,6 +59,7 @@ func newConnectionsContainer(
 		log.Infof("Migrations applied successfully")
 		multiCloser.Add(db)
 		container.db = db
+		container.connectionPool = mysql.NewConnectionPool(mysql.NewTransactionalClientFromSQLx(db))
 
 		// TODO: это конекшены к другим сервисам (в данном случае - gRPC)
 		testConnection, err := grpc.NewClient(
@@ -68,6 +73,36 @@ func newConnectionsContainer(
 		multiCloser.Add(testConnection)
 		container.testConnection = testConnection
 
+		productConnection, err := grpc.NewClient(
+			config.ProductGRPCAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return err
+		}
+		multiCloser.Add(productConnection)
+		container.productConnection = productConnection
+
+		paymentConnection, err := grpc.NewClient(
+			config.PaymentGRPCAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return err
+		}
+		multiCloser.Add(paymentConnection)
+		container.paymentConnection = paymentConnection
+
+		temporalClient, err := temporal.NewClient(newLibLogger(logger), config.TemporalHost)
+		if err != nil {
+			return fmt.Errorf("failed to create temporal client: %w", err)
+		}
+		multiCloser.Add(libio.CloserFunc(func() error {
+			temporalClient.Close()
+			return nil
+		}))
+		container.temporalClient = temporalClient
+
 		return nil
 	}
 
@@ -76,7 +111,12 @@ func newConnectionsContainer(
 
 type connectionsContainer struct {
 	db             *sqlx.DB
+	connectionPool mysql.ConnectionPool
 	testConnection grpc.ClientConnInterface
+
+	productConnection grpc.ClientConnInterface
+	paymentConnection grpc.ClientConnInterface
+	temporalClient    client.Client
 }
 
 func initMySQL(cfg *config) (db *sqlx.DB, err error) {
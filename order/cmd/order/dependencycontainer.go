@@ -1,18 +1,49 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	log "github.com/sirupsen/logrus"
 
-// TODO: добавить зависимости
+	paymentapi "order/api/client/paymentinternal"
+	productapi "order/api/client/productinternal"
+	"order/pkg/application/query"
+	appservice "order/pkg/application/service"
+	"order/pkg/infrastructure/integrationevent"
+	inframysql "order/pkg/infrastructure/mysql"
+	mysqlquery "order/pkg/infrastructure/mysql/query"
+	"order/pkg/infrastructure/productcatalog"
+	"order/pkg/infrastructure/temporal"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	_ *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	libUoW := mysql.NewUnitOfWork(connContainer.connectionPool, inframysql.NewRepositoryProvider)
+	uow := inframysql.NewUnitOfWork(libUoW)
+	productClient := productapi.NewProductInternalServiceClient(connContainer.productConnection)
+	productCatalogProvider := productcatalog.NewProductCatalogProvider(productClient)
+	eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		orderService:           appservice.NewOrderService(uow, productCatalogProvider, eventDispatcher),
+		workflowService:        temporal.NewWorkflowService(connContainer.temporalClient),
+		productCatalogProvider: productCatalogProvider,
+		productClient:          productClient,
+		paymentClient:          paymentapi.NewPaymentInternalServiceClient(connContainer.paymentConnection),
+		orderQueryService:      mysqlquery.NewOrderQueryService(connContainer.db),
+		idempotencyKeyStore:    inframysql.NewIdempotencyKeyStore(connContainer.db, config.IdempotencyKeyTTL, config.IdempotencyKeyLease),
 	}, nil
 }
 
 type dependencyContainer struct {
-	db *sqlx.DB
+	orderService           appservice.OrderService
+	workflowService        temporal.WorkflowService
+	productCatalogProvider appservice.ProductCatalogProvider
+	productClient          productapi.ProductInternalServiceClient
+	paymentClient          paymentapi.PaymentInternalServiceClient
+	orderQueryService      query.OrderQueryService
+	idempotencyKeyStore    appservice.IdempotencyKeyStore
 }
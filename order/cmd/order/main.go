@@ -57,6 +57,8 @@ func runApp(
 		Name: appID,
 		Commands: []*cli.Command{
 			service(config, logger, closer),
+			messageHandler(config, logger, closer),
+			workflowWorker(config, logger, closer),
 			migrate(config, logger),
 		},
 	}
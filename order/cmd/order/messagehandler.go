@@ -0,0 +1,100 @@
+package main
+
+import (
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"order/pkg/infrastructure/integrationevent"
+)
+
+func messageHandler(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "message-handler",
+		Usage: "Publishes stored domain events to AMQP and handles events of other services",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			container, err := newDependencyContainer(config, logger, connContainer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init dependencies")
+			}
+
+			libLogger := newLibLogger(logger)
+			amqpConnection := amqp.NewAMQPConnection(appID, &amqp.ConnectionConfig{
+				User:           config.AMQPUser,
+				Password:       config.AMQPPassword,
+				Host:           config.AMQPHost,
+				ConnectTimeout: config.AMQPConnectTimeout,
+			}, libLogger)
+			amqpEventProducer := amqpConnection.Producer(
+				&amqp.ExchangeConfig{
+					Name:    integrationevent.ExchangeName,
+					Kind:    integrationevent.ExchangeKind,
+					Durable: true,
+				},
+				nil,
+				nil,
+			)
+			amqpTransport := integrationevent.NewAMQPTransport(libLogger, container.orderService, container.orderQueryService)
+			amqpConnection.Consumer(
+				c.Context,
+				amqpTransport.Handler(),
+				&amqp.QueueConfig{
+					Name:    integrationevent.PaymentEventsQueueName,
+					Durable: true,
+				},
+				&amqp.BindConfig{
+					QueueName:    integrationevent.PaymentEventsQueueName,
+					ExchangeName: integrationevent.ExchangeName,
+					RoutingKeys:  []string{integrationevent.PaymentRoutingKeyPrefix + "#"},
+				},
+				&amqp.QoSConfig{
+					PrefetchCount: 100,
+				},
+			)
+			amqpConnection.Consumer(
+				c.Context,
+				amqpTransport.Handler(),
+				&amqp.QueueConfig{
+					Name:    integrationevent.ProductEventsQueueName,
+					Durable: true,
+				},
+				&amqp.BindConfig{
+					QueueName:    integrationevent.ProductEventsQueueName,
+					ExchangeName: integrationevent.ExchangeName,
+					RoutingKeys: []string{
+						integrationevent.ProductRoutingKeyPrefix + "ProductUpdated",
+						integrationevent.ProductRoutingKeyPrefix + "ProductDeleted",
+					},
+				},
+				&amqp.QoSConfig{
+					PrefetchCount: 100,
+				},
+			)
+			err = amqpConnection.Start()
+			if err != nil {
+				return errors.Wrap(err, "failed to start AMQP connection")
+			}
+			closer.Add(libio.CloserFunc(amqpConnection.Stop))
+
+			outboxEventHandler := outbox.NewEventHandler(outbox.EventHandlerConfig{
+				TransportName:  integrationevent.TransportName,
+				Transport:      integrationevent.NewOutboxTransport(libLogger, amqpEventProducer),
+				ConnectionPool: connContainer.connectionPool,
+				Logger:         libLogger,
+			})
+			return outboxEventHandler.Start(c.Context)
+		},
+	}
+}
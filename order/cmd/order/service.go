@@ -30,7 +30,7 @@ func service(
 				return errors.Wrap(err, "failed to init connections")
 			}
 
-			container, err := newDependencyContainer(config, connContainer)
+			container, err := newDependencyContainer(config, logger, connContainer)
 			if err != nil {
 				return errors.Wrap(err, "failed to init dependencies")
 			}
@@ -43,12 +43,15 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
 ) error {
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		makeGrpcUnaryInterceptor(logger),
+		transport.MakeActorServerInterceptor(),
+		transport.MakeIdempotencyServerInterceptor(container.idempotencyKeyStore, logger),
+	))
 
-	// TODO: зарегистрировать свой сервер вместо шаблонного
-	api.RegisterOrderInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterOrderInternalServiceServer(grpcServer, transport.NewInternalAPI(container.orderService, container.orderQueryService, container.workflowService))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
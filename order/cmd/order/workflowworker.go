@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"order/pkg/infrastructure/temporal/worker"
+)
+
+func workflowWorker(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "workflow-worker",
+		Usage: "Runs Temporal workflows and activities of the order service",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			container, err := newDependencyContainer(config, logger, connContainer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init dependencies")
+			}
+
+			err = container.workflowService.StartPendingOrdersExpiration(c.Context, config.PendingOrderTTL)
+			if err != nil {
+				return errors.Wrap(err, "failed to start pending orders expiration")
+			}
+
+			w := worker.NewWorker(
+				connContainer.temporalClient,
+				container.orderService,
+				container.orderQueryService,
+				container.productCatalogProvider,
+				container.productClient,
+				container.paymentClient,
+			)
+			return w.Run(worker.InterruptChannel())
+		},
+	}
+}
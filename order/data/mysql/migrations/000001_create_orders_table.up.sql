@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS orders
+(
+    `order_id`    VARCHAR(64) NOT NULL,
+    `customer_id` VARCHAR(64) NOT NULL,
+    `status`      INT         NOT NULL,
+    `created_at`  DATETIME    NOT NULL,
+    `updated_at`  DATETIME    NOT NULL,
+    `deleted_at`  DATETIME,
+    PRIMARY KEY (`order_id`),
+    INDEX `customer_id_idx` (`customer_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS order_item;
\ No newline at end of file
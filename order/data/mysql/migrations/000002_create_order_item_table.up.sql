@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_item
+(
+    `item_id`    VARCHAR(64)    NOT NULL,
+    `order_id`   VARCHAR(64)    NOT NULL,
+    `product_id` VARCHAR(64)    NOT NULL,
+    `price`      DECIMAL(19, 4) NOT NULL,
+    PRIMARY KEY (`item_id`),
+    INDEX `order_id_idx` (`order_id`),
+    CONSTRAINT `order_item_order_id_fk` FOREIGN KEY (`order_id`) REFERENCES orders (`order_id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS outbox_domain_tracked_event
+(
+    `transport_name`        VARBINARY(128) NOT NULL,
+    `last_tracked_event_id` BIGINT         NOT NULL,
+    PRIMARY KEY (`transport_name`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
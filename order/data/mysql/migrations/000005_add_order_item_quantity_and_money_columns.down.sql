@@ -0,0 +1,5 @@
+ALTER TABLE order_item
+    DROP COLUMN `quantity`,
+    DROP COLUMN `price_amount`,
+    DROP COLUMN `currency`
+;
@@ -0,0 +1,5 @@
+ALTER TABLE order_item
+    ADD COLUMN `quantity`     INT         NOT NULL DEFAULT 1 AFTER `product_id`,
+    ADD COLUMN `price_amount` BIGINT      NOT NULL DEFAULT 0 AFTER `quantity`,
+    ADD COLUMN `currency`     VARCHAR(3)  NOT NULL DEFAULT 'RUB' AFTER `price_amount`
+;
@@ -0,0 +1 @@
+UPDATE order_item SET `price` = `price_amount` / 100;
@@ -0,0 +1 @@
+UPDATE order_item SET `price_amount` = ROUND(`price` * 100);
@@ -0,0 +1 @@
+ALTER TABLE order_item ADD COLUMN `price` DECIMAL(19, 4) NOT NULL DEFAULT 0 AFTER `product_id`;
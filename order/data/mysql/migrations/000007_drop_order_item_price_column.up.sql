@@ -0,0 +1 @@
+ALTER TABLE order_item DROP COLUMN `price`;
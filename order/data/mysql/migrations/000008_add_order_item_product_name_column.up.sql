@@ -0,0 +1 @@
+ALTER TABLE order_item ADD COLUMN `product_name` VARCHAR(255) NOT NULL DEFAULT '' AFTER `product_id`;
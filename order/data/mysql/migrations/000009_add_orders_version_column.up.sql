@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN `version` INT NOT NULL DEFAULT 1;
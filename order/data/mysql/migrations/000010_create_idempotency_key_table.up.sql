@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_key
+(
+    `method`          VARCHAR(255) NOT NULL,
+    `idempotency_key` VARCHAR(255) NOT NULL,
+    `request_hash`    VARCHAR(64)  NOT NULL,
+    `response`        BLOB,
+    `created_at`      DATETIME     NOT NULL,
+    PRIMARY KEY (`method`, `idempotency_key`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
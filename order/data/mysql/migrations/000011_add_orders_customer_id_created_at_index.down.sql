@@ -0,0 +1 @@
+ALTER TABLE orders DROP INDEX `customer_id_created_at_idx`;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD INDEX `customer_id_created_at_idx` (`customer_id`, `created_at`);
@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ADD COLUMN `cancellation_reason` VARCHAR(255),
+    ADD COLUMN `cancelled_by`        VARCHAR(64),
+    ADD COLUMN `cancelled_at`        DATETIME
+;
@@ -0,0 +1 @@
+ALTER TABLE orders DROP INDEX `status_updated_at_idx`;
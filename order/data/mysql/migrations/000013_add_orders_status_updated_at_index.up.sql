@@ -0,0 +1 @@
+ALTER TABLE orders ADD INDEX `status_updated_at_idx` (`status`, `updated_at`);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS order_history
+(
+    `id`          BIGINT       NOT NULL AUTO_INCREMENT,
+    `order_id`    VARCHAR(64)  NOT NULL,
+    `event_type`  VARCHAR(255) NOT NULL,
+    `payload`     TEXT         NOT NULL,
+    `actor`       VARCHAR(64)  NOT NULL,
+    `occurred_at` DATETIME(6)  NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `order_id_idx` (`order_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS processed_payment_transaction
+(
+    `transaction_id` VARCHAR(64) NOT NULL,
+    `order_id`       VARCHAR(64) NOT NULL,
+    `processed_at`   DATETIME    NOT NULL,
+    PRIMARY KEY (`transaction_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
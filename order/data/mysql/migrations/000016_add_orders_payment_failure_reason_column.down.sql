@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN `payment_failure_reason`;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN `payment_failure_reason` VARCHAR(255) NOT NULL DEFAULT '';
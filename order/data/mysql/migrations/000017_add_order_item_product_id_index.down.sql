@@ -0,0 +1 @@
+ALTER TABLE order_item DROP INDEX `product_id_idx`;
@@ -0,0 +1 @@
+ALTER TABLE order_item ADD INDEX `product_id_idx` (`product_id`);
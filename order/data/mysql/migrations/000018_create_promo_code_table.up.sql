@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS promo_code
+(
+    `code`                  VARCHAR(64) NOT NULL,
+    `discount_type`         TINYINT     NOT NULL,
+    `discount_percent`      INT         NOT NULL DEFAULT 0,
+    `discount_amount`       BIGINT      NOT NULL DEFAULT 0,
+    `discount_currency`     VARCHAR(3)  NOT NULL DEFAULT '',
+    `discount_product_id`   VARCHAR(64),
+    `discount_buy_quantity` INT         NOT NULL DEFAULT 0,
+    `valid_from`            DATETIME    NOT NULL,
+    `valid_to`              DATETIME,
+    `usage_limit`           INT         NOT NULL DEFAULT 0,
+    PRIMARY KEY (`code`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
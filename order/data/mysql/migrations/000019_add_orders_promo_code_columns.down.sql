@@ -0,0 +1,10 @@
+ALTER TABLE orders
+    DROP INDEX `customer_id_promo_code_idx`,
+    DROP COLUMN `promo_code`,
+    DROP COLUMN `discount_type`,
+    DROP COLUMN `discount_percent`,
+    DROP COLUMN `discount_amount`,
+    DROP COLUMN `discount_currency`,
+    DROP COLUMN `discount_product_id`,
+    DROP COLUMN `discount_buy_quantity`
+;
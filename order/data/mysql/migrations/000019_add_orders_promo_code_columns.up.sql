@@ -0,0 +1,10 @@
+ALTER TABLE orders
+    ADD COLUMN `promo_code`            VARCHAR(64),
+    ADD COLUMN `discount_type`         TINYINT,
+    ADD COLUMN `discount_percent`      INT,
+    ADD COLUMN `discount_amount`       BIGINT,
+    ADD COLUMN `discount_currency`     VARCHAR(3),
+    ADD COLUMN `discount_product_id`   VARCHAR(64),
+    ADD COLUMN `discount_buy_quantity` INT,
+    ADD INDEX `customer_id_promo_code_idx` (`customer_id`, `promo_code`)
+;
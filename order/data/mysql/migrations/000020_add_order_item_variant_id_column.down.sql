@@ -0,0 +1 @@
+ALTER TABLE order_item DROP COLUMN `variant_id`;
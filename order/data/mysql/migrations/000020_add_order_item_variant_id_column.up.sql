@@ -0,0 +1,2 @@
+-- nil uuid is stored for items of product without variant
+ALTER TABLE order_item ADD COLUMN `variant_id` VARCHAR(64) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' AFTER `product_id`;
@@ -0,0 +1,2 @@
+-- reserved_at is refreshed when stale reservation of crashed call is taken over
+ALTER TABLE idempotency_key ADD COLUMN `reserved_at` DATETIME NULL AFTER `response`;
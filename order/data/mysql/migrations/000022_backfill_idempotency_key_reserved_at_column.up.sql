@@ -0,0 +1 @@
+UPDATE idempotency_key SET `reserved_at` = `created_at`;
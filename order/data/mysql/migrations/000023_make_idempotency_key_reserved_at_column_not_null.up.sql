@@ -0,0 +1 @@
+ALTER TABLE idempotency_key MODIFY COLUMN `reserved_at` DATETIME NOT NULL;
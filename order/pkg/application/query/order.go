@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type OrderSortField int
+
+const (
+	SortByCreatedAt OrderSortField = iota
+	SortByUpdatedAt
+)
+
+// ListOrdersSpec filters orders, zero values of fields mean no filtering
+type ListOrdersSpec struct {
+	CustomerID *uuid.UUID
+	// ProductID selects orders having items of the product
+	ProductID *uuid.UUID
+	Statuses  []model.OrderStatus
+	// CreatedFrom is inclusive and CreatedTo is exclusive bound of order creation time
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// UpdatedBefore is exclusive bound of order last modification time
+	UpdatedBefore *time.Time
+
+	SortBy     OrderSortField
+	Descending bool
+
+	// Cursor is taken from OrderPage.NextCursor of the previous page and must be used with the same spec
+	Cursor   string
+	PageSize int
+}
+
+type OrderPage struct {
+	Orders []*model.Order
+	// NextCursor is empty for the last page
+	NextCursor string
+}
+
+type OrderHistoryEntry struct {
+	EventType string
+	// Payload is JSON of the event in the same shape as published integration event
+	Payload    string
+	Actor      string
+	OccurredAt time.Time
+}
+
+type OrderQueryService interface {
+	ListOrders(ctx context.Context, spec ListOrdersSpec) (OrderPage, error)
+	// GetOrderHistory returns events of the order in order of occurrence, history of deleted orders is kept
+	GetOrderHistory(ctx context.Context, orderID uuid.UUID) ([]OrderHistoryEntry, error)
+}
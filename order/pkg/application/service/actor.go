@@ -0,0 +1,20 @@
+package service
+
+import "context"
+
+// UnknownActor is recorded when the caller did not introduce itself
+const UnknownActor = "unknown"
+
+type actorKey struct{}
+
+// WithActor binds ID of the user or service making the call to the context
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return UnknownActor
+}
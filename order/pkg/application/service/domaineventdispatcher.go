@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/pkg/errors"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+// domainEventDispatcher publishes events through outbox and records them to order history in the same transaction
+type domainEventDispatcher struct {
+	ctx               context.Context
+	eventDispatcher   outbox.EventDispatcher[outbox.Event]
+	historyRepository model.OrderHistoryRepository
+}
+
+func (d *domainEventDispatcher) Dispatch(event service.Event) error {
+	err := d.eventDispatcher.Dispatch(d.ctx, event)
+	if err != nil {
+		return err
+	}
+
+	orderEvent, ok := event.(model.OrderEvent)
+	if !ok {
+		return errors.Errorf("event %q does not belong to order", event.Type())
+	}
+	return d.historyRepository.Append(orderEvent, ActorFromContext(d.ctx), time.Now())
+}
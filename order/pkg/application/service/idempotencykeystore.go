@@ -0,0 +1,19 @@
+package service
+
+import "context"
+
+// IdempotencyRecord is a stored call of method, Response is nil while the first call is in progress
+type IdempotencyRecord struct {
+	RequestHash string
+	Response    []byte
+}
+
+// IdempotencyKeyStore keeps results of calls by method and idempotency key within its expiration window
+type IdempotencyKeyStore interface {
+	// Reserve claims key for the first call and returns nil, otherwise returns record of the first call.
+	// Reservation of the call that has not completed within the lease is claimed anew
+	Reserve(ctx context.Context, method, key, requestHash string) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, method, key string, response []byte) error
+	// Release drops reservation of failed call, so it can be retried with the same key
+	Release(ctx context.Context, method, key string) error
+}
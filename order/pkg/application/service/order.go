@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+// maxConflictRetries bounds re-execution of a unit of work that lost the optimistic locking race
+const maxConflictRetries = 3
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, customerID uuid.UUID) (uuid.UUID, error)
+	DeleteOrder(ctx context.Context, orderID uuid.UUID) error
+	SetStatus(ctx context.Context, orderID uuid.UUID, status model.OrderStatus) error
+	// CancelOrder records actor bound to the context as the one who cancelled order, the actor is required
+	CancelOrder(ctx context.Context, orderID uuid.UUID, reason string) error
+	ExpirePendingOrder(ctx context.Context, orderID uuid.UUID, pendingBefore time.Time) error
+	// HandlePaymentSucceeded and HandlePaymentFailed apply payment transaction to order at most once
+	HandlePaymentSucceeded(ctx context.Context, transactionID, orderID uuid.UUID) error
+	HandlePaymentFailed(ctx context.Context, transactionID, orderID uuid.UUID, reason string) error
+
+	AddItem(ctx context.Context, orderID uuid.UUID, productID uuid.UUID, variantID uuid.UUID, quantity int) (uuid.UUID, error)
+	DeleteItem(ctx context.Context, orderID uuid.UUID, itemID uuid.UUID) error
+	RemoveProductItems(ctx context.Context, orderID uuid.UUID, productID uuid.UUID) error
+	RefreshProductItems(ctx context.Context, orderID uuid.UUID, product model.Product) error
+
+	ApplyPromoCode(ctx context.Context, orderID uuid.UUID, code string) error
+
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error)
+}
+
+func NewOrderService(
+	uow UnitOfWork,
+	productCatalogProvider ProductCatalogProvider,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) OrderService {
+	return &orderService{
+		uow:                    uow,
+		productCatalogProvider: productCatalogProvider,
+		eventDispatcher:        eventDispatcher,
+	}
+}
+
+type orderService struct {
+	uow                    UnitOfWork
+	productCatalogProvider ProductCatalogProvider
+	eventDispatcher        outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, customerID uuid.UUID) (uuid.UUID, error) {
+	var orderID uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		orderID, err = s.domainService(ctx, provider).CreateOrder(customerID)
+		return err
+	})
+	return orderID, err
+}
+
+func (s *orderService) DeleteOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteOrder(orderID)
+	})
+}
+
+func (s *orderService) SetStatus(ctx context.Context, orderID uuid.UUID, status model.OrderStatus) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetStatus(orderID, status)
+	})
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID, reason string) error {
+	cancelledBy := ActorFromContext(ctx)
+	if cancelledBy == UnknownActor {
+		return model.ErrInvalidCancellation
+	}
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).CancelOrder(orderID, reason, cancelledBy)
+	})
+}
+
+func (s *orderService) ExpirePendingOrder(ctx context.Context, orderID uuid.UUID, pendingBefore time.Time) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ExpirePendingOrder(orderID, pendingBefore)
+	})
+}
+
+func (s *orderService) HandlePaymentSucceeded(ctx context.Context, transactionID, orderID uuid.UUID) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		first, err := provider.ProcessedPaymentRepository(ctx).MarkProcessed(transactionID, orderID)
+		if err != nil || !first {
+			return err
+		}
+		return s.domainService(ctx, provider).ConfirmPayment(orderID)
+	})
+}
+
+func (s *orderService) HandlePaymentFailed(ctx context.Context, transactionID, orderID uuid.UUID, reason string) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		first, err := provider.ProcessedPaymentRepository(ctx).MarkProcessed(transactionID, orderID)
+		if err != nil || !first {
+			return err
+		}
+		return s.domainService(ctx, provider).RejectPayment(orderID, reason)
+	})
+}
+
+func (s *orderService) AddItem(ctx context.Context, orderID uuid.UUID, productID uuid.UUID, variantID uuid.UUID, quantity int) (uuid.UUID, error) {
+	var itemID uuid.UUID
+	err := s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		var err error
+		itemID, err = s.domainService(ctx, provider).AddItem(orderID, productID, variantID, quantity)
+		return err
+	})
+	return itemID, err
+}
+
+func (s *orderService) DeleteItem(ctx context.Context, orderID uuid.UUID, itemID uuid.UUID) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteItem(orderID, itemID)
+	})
+}
+
+func (s *orderService) RemoveProductItems(ctx context.Context, orderID uuid.UUID, productID uuid.UUID) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RemoveProductItems(orderID, productID)
+	})
+}
+
+func (s *orderService) RefreshProductItems(ctx context.Context, orderID uuid.UUID, product model.Product) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RefreshProductItems(orderID, product)
+	})
+}
+
+func (s *orderService) ApplyPromoCode(ctx context.Context, orderID uuid.UUID, code string) error {
+	return s.executeWithRetry(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ApplyPromoCode(orderID, code)
+	})
+}
+
+func (s *orderService) GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error) {
+	var order *model.Order
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		order, err = s.domainService(ctx, provider).GetOrder(orderID)
+		return err
+	})
+	return order, err
+}
+
+// executeWithRetry re-runs f in a new transaction when order was modified concurrently
+func (s *orderService) executeWithRetry(ctx context.Context, f func(provider RepositoryProvider) error) error {
+	var err error
+	for range maxConflictRetries {
+		err = s.uow.Execute(ctx, f)
+		if !errors.Is(err, model.ErrOrderVersionConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *orderService) domainService(ctx context.Context, provider RepositoryProvider) service.Order {
+	return service.NewOrderService(
+		provider.OrderRepository(ctx),
+		provider.PromoCodeRepository(ctx),
+		s.productCatalogProvider.ProductCatalog(ctx),
+		s.domainEventDispatcher(ctx, provider),
+	)
+}
+
+func (s *orderService) domainEventDispatcher(ctx context.Context, provider RepositoryProvider) service.EventDispatcher {
+	return &domainEventDispatcher{
+		ctx:               ctx,
+		eventDispatcher:   s.eventDispatcher,
+		historyRepository: provider.OrderHistoryRepository(ctx),
+	}
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"order/pkg/domain/model"
+)
+
+// ProductCatalogProvider binds product catalog to the context of the current call
+type ProductCatalogProvider interface {
+	ProductCatalog(ctx context.Context) model.ProductCatalog
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+type RepositoryProvider interface {
+	OrderRepository(ctx context.Context) model.OrderRepository
+	OrderHistoryRepository(ctx context.Context) model.OrderHistoryRepository
+	PromoCodeRepository(ctx context.Context) model.PromoCodeRepository
+	ProcessedPaymentRepository(ctx context.Context) ProcessedPaymentRepository
+}
+
+// ProcessedPaymentRepository remembers payment transactions already applied to orders
+type ProcessedPaymentRepository interface {
+	// MarkProcessed returns false if transaction has already been processed
+	MarkProcessed(transactionID, orderID uuid.UUID) (bool, error)
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
@@ -11,16 +11,27 @@ func (e OrderCreated) Type() string {
 	return "OrderCreated"
 }
 
+func (e OrderCreated) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderItemChanged struct {
 	OrderID      uuid.UUID
 	AddedItems   []uuid.UUID
 	RemovedItems []uuid.UUID
+	// ChangedItems are repriced or renamed after their product changed in catalog
+	ChangedItems []uuid.UUID
+	Total        Money
 }
 
 func (e OrderItemChanged) Type() string {
 	return "OrderItemChanged"
 }
 
+func (e OrderItemChanged) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderRemoved struct {
 	OrderID uuid.UUID
 }
@@ -29,20 +40,76 @@ func (e OrderRemoved) Type() string {
 	return "OrderRemoved"
 }
 
+func (e OrderRemoved) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderStatusChanged struct {
-	OrderID uuid.UUID
-	Status  OrderStatus
+	OrderID   uuid.UUID
+	OldStatus OrderStatus
+	NewStatus OrderStatus
 }
 
 func (e OrderStatusChanged) Type() string {
 	return "OrderStatusChanged"
 }
 
+func (e OrderStatusChanged) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
 type OrderItemRemoved struct {
 	OrderID uuid.UUID
 	ItemID  uuid.UUID
+	Total   Money
 }
 
 func (e OrderItemRemoved) Type() string {
 	return "OrderItemRemoved"
 }
+
+func (e OrderItemRemoved) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+type OrderCancelled struct {
+	OrderID     uuid.UUID
+	Reason      string
+	CancelledBy string
+}
+
+func (e OrderCancelled) Type() string {
+	return "OrderCancelled"
+}
+
+func (e OrderCancelled) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+type OrderPaymentFailed struct {
+	OrderID uuid.UUID
+	Reason  string
+}
+
+func (e OrderPaymentFailed) Type() string {
+	return "OrderPaymentFailed"
+}
+
+func (e OrderPaymentFailed) AggregateID() uuid.UUID {
+	return e.OrderID
+}
+
+type OrderPromoCodeApplied struct {
+	OrderID  uuid.UUID
+	Code     string
+	Discount Money
+	Total    Money
+}
+
+func (e OrderPromoCodeApplied) Type() string {
+	return "OrderPromoCodeApplied"
+}
+
+func (e OrderPromoCodeApplied) AggregateID() uuid.UUID {
+	return e.OrderID
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrNegativeAmount   = errors.New("amount cannot be negative")
+)
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+const DefaultCurrency Currency = "RUB"
+
+// Money keeps amount in minor units (e.g. kopecks) to avoid floating point rounding errors
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if amount < 0 {
+		return Money{}, ErrNegativeAmount
+	}
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}, nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.IsZero() && m.Currency == "" {
+		return other, nil
+	}
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{
+		Amount:   m.Amount + other.Amount,
+		Currency: m.Currency,
+	}, nil
+}
+
+func (m Money) Multiply(quantity int) Money {
+	return Money{
+		Amount:   m.Amount * int64(quantity),
+		Currency: m.Currency,
+	}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d.%02d %s", m.Amount/100, m.Amount%100, m.Currency)
+}
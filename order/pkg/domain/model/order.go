@@ -2,12 +2,26 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var ErrOrderNotFound = errors.New("order not found")
+var (
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderItemNotFound       = errors.New("order item not found")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidQuantity         = errors.New("item quantity must be positive")
+	ErrOrderVersionConflict    = errors.New("order was modified concurrently")
+	ErrInvalidCancellation     = errors.New("cancellation reason and actor are required")
+)
+
+// SystemActor cancels orders on behalf of the service itself, e.g. when payment has not arrived in time
+const SystemActor = "system"
+
+// PendingExpiredReason is a reason of cancellation of order whose payment has not arrived in time
+const PendingExpiredReason = "payment was not received in time"
 
 type OrderStatus int
 
@@ -18,6 +32,51 @@ const (
 	Cancelled
 )
 
+// statusTransitions lists statuses order can be moved to from the given one
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	Open:      {Pending, Cancelled},
+	Pending:   {Open, Paid, Cancelled},
+	Paid:      {},
+	Cancelled: {},
+}
+
+func (s OrderStatus) CanTransitTo(status OrderStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (s OrderStatus) String() string {
+	switch s {
+	case Open:
+		return "Open"
+	case Pending:
+		return "Pending"
+	case Paid:
+		return "Paid"
+	case Cancelled:
+		return "Cancelled"
+	default:
+		return fmt.Sprintf("OrderStatus(%d)", int(s))
+	}
+}
+
+type StatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidStatusTransition, e.From, e.To)
+}
+
+func (e StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
 type Order struct {
 	ID         uuid.UUID
 	CustomerID uuid.UUID
@@ -26,17 +85,68 @@ type Order struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DeletedAt  *time.Time
+	// Cancellation is set only for orders cancelled with CancelOrder
+	Cancellation *Cancellation
+	// PaymentFailureReason is set when the last payment of the order has been declined
+	PaymentFailureReason string
+	// PromoCode is set when discount is applied to the order
+	PromoCode *AppliedPromoCode
+	// Version is incremented on every Store, stale version makes Store fail with ErrOrderVersionConflict.
+	// Zero version means order was never stored
+	Version int
+}
+
+// Subtotal sums up items subtotals, items are guaranteed to share one currency
+func (o *Order) Subtotal() Money {
+	var subtotal Money
+	for _, item := range o.Items {
+		// error is impossible since currency is checked when item is added
+		subtotal, _ = subtotal.Add(item.Subtotal())
+	}
+	return subtotal
+}
+
+func (o *Order) Discount() Money {
+	if o.PromoCode == nil {
+		return Money{Currency: o.Subtotal().Currency}
+	}
+	return o.PromoCode.Rule.Discount(o.Items)
+}
+
+// Total is subtotal with discount applied
+func (o *Order) Total() Money {
+	subtotal := o.Subtotal()
+	subtotal.Amount -= o.Discount().Amount
+	return subtotal
+}
+
+type Cancellation struct {
+	Reason string
+	// CancelledBy is ID of the user who cancelled order or SystemActor
+	CancelledBy string
+	CancelledAt time.Time
 }
 
 type Item struct {
 	ID        uuid.UUID
 	ProductID uuid.UUID
-	Price     float64
+	// VariantID is uuid.Nil for items of product without variant
+	VariantID uuid.UUID
+	// ProductName and Price are snapshotted from catalog when item is added
+	ProductName string
+	Quantity    int
+	// Price is a price of a single unit
+	Price Money
+}
+
+func (i Item) Subtotal() Money {
+	return i.Price.Multiply(i.Quantity)
 }
 
 type OrderRepository interface {
 	NextID() (uuid.UUID, error)
 	Store(order *Order) error
 	Find(id uuid.UUID) (*Order, error)
-	Delete(id uuid.UUID) error
+	// Delete soft deletes order only if it has not been changed since it was read, like Store does
+	Delete(order *Order) error
 }
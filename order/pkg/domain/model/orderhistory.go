@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderEvent is implemented by every order domain event
+type OrderEvent interface {
+	Type() string
+	AggregateID() uuid.UUID
+}
+
+// OrderHistoryRepository is an append-only audit log of order events, records are never updated or removed
+type OrderHistoryRepository interface {
+	Append(event OrderEvent, actor string, occurredAt time.Time) error
+}
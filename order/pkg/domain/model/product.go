@@ -0,0 +1,24 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrProductNotFound = errors.New("product not found")
+
+// Product is a view of product service catalog entry, or of its variant when VariantID is not uuid.Nil
+type Product struct {
+	ID        uuid.UUID
+	VariantID uuid.UUID
+	// Name of variant includes its attributes
+	Name  string
+	Price Money
+}
+
+// ProductCatalog is a read-only port to product service, deleted products and variants are reported as ErrProductNotFound.
+// Passing uuid.Nil as variantID finds product itself
+type ProductCatalog interface {
+	FindProduct(id uuid.UUID, variantID uuid.UUID) (Product, error)
+}
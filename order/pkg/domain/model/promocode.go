@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPromoCodeNotFound           = errors.New("promo code not found")
+	ErrPromoCodeNotActive          = errors.New("promo code is not active")
+	ErrPromoCodeUsageLimitExceeded = errors.New("promo code usage limit exceeded")
+)
+
+type DiscountType int
+
+const (
+	PercentageDiscount DiscountType = iota
+	FixedDiscount
+	// BuyNGetOneFreeDiscount makes every unit after BuyQuantity units of the product free
+	BuyNGetOneFreeDiscount
+)
+
+// DiscountRule is snapshotted to order when promo code is applied, so later changes of promo code do not affect it
+type DiscountRule struct {
+	Type DiscountType
+	// Percent is used by PercentageDiscount
+	Percent int
+	// Amount is used by FixedDiscount
+	Amount Money
+	// ProductID and BuyQuantity are used by BuyNGetOneFreeDiscount
+	ProductID   uuid.UUID
+	BuyQuantity int
+}
+
+// Discount is never greater than items subtotal, rule in currency other than items one gives no discount
+func (r DiscountRule) Discount(items []Item) Money {
+	var subtotal Money
+	for _, item := range items {
+		subtotal, _ = subtotal.Add(item.Subtotal())
+	}
+
+	var amount int64
+	switch r.Type {
+	case PercentageDiscount:
+		amount = subtotal.Amount * int64(r.Percent) / 100
+	case FixedDiscount:
+		if r.Amount.Currency == subtotal.Currency {
+			amount = r.Amount.Amount
+		}
+	case BuyNGetOneFreeDiscount:
+		if r.BuyQuantity <= 0 {
+			break
+		}
+		for _, item := range items {
+			if item.ProductID == r.ProductID {
+				amount += item.Price.Multiply(item.Quantity / (r.BuyQuantity + 1)).Amount
+			}
+		}
+	}
+	return Money{
+		Amount:   max(0, min(amount, subtotal.Amount)),
+		Currency: subtotal.Currency,
+	}
+}
+
+type PromoCode struct {
+	Code string
+	Rule DiscountRule
+	// ValidFrom is inclusive and ValidTo is exclusive bound of promo code validity, nil ValidTo means no end
+	ValidFrom time.Time
+	ValidTo   *time.Time
+	// UsageLimit bounds number of orders of one customer with the promo code, zero means no limit
+	UsageLimit int
+}
+
+func (p PromoCode) IsActive(t time.Time) bool {
+	return !t.Before(p.ValidFrom) && (p.ValidTo == nil || t.Before(*p.ValidTo))
+}
+
+// AppliedPromoCode is kept by order the promo code is applied to
+type AppliedPromoCode struct {
+	Code string
+	Rule DiscountRule
+}
+
+type PromoCodeRepository interface {
+	// Find locks promo code until the end of the transaction, so its usages are counted and changed by one order at a time
+	Find(code string) (*PromoCode, error)
+	// CountUsages counts not cancelled orders of the customer with the promo code applied except excludedOrderID,
+	// orders committed after the transaction has started are counted too
+	CountUsages(code string, customerID uuid.UUID, excludedOrderID uuid.UUID) (int, error)
+}
@@ -24,22 +24,53 @@ type EventDispatcher interface {
 
 type Order interface {
 	CreateOrder(customerID uuid.UUID) (uuid.UUID, error)
+	// DeleteOrder deletes only Open orders
 	DeleteOrder(orderID uuid.UUID) error
 	SetStatus(orderID uuid.UUID, status model.OrderStatus) error
+	// CancelOrder cancels order recording reason and who cancelled it, cancelling of cancelled order does nothing
+	CancelOrder(orderID uuid.UUID, reason string, cancelledBy string) error
+	// ConfirmPayment moves Pending order to Paid, orders in other statuses are left intact
+	ConfirmPayment(orderID uuid.UUID) error
+	// RejectPayment moves Pending order back to Open recording failure reason, orders in other statuses are left intact
+	RejectPayment(orderID uuid.UUID, reason string) error
+	// ExpirePendingOrder cancels order staying Pending since before pendingBefore, other orders are left intact
+	ExpirePendingOrder(orderID uuid.UUID, pendingBefore time.Time) error
 
-	AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (uuid.UUID, error)
+	// AddItem adds quantity of product or its variant to order with name and price taken from catalog,
+	// uuid.Nil variantID adds product itself. For already added product variant quantity is increased and snapshot is refreshed
+	AddItem(orderID uuid.UUID, productID uuid.UUID, variantID uuid.UUID, quantity int) (uuid.UUID, error)
+	// DeleteItem removes item from Open order
 	DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error
+	// RemoveProductItems removes items of deleted product from Open order, orders in other statuses are left intact
+	RemoveProductItems(orderID uuid.UUID, productID uuid.UUID) error
+	// RefreshProductItems updates name and price snapshot of product items in Open order,
+	// orders in other statuses and items of product variants are left intact
+	RefreshProductItems(orderID uuid.UUID, product model.Product) error
+
+	// ApplyPromoCode applies discount of the active promo code to Open order replacing previously applied one
+	ApplyPromoCode(orderID uuid.UUID, code string) error
+
+	GetOrder(orderID uuid.UUID) (*model.Order, error)
 }
 
-func NewOrderService(repo model.OrderRepository, dispatcher EventDispatcher) Order {
+func NewOrderService(
+	repo model.OrderRepository,
+	promoCodes model.PromoCodeRepository,
+	catalog model.ProductCatalog,
+	dispatcher EventDispatcher,
+) Order {
 	return &orderService{
 		repo:       repo,
+		promoCodes: promoCodes,
+		catalog:    catalog,
 		dispatcher: dispatcher,
 	}
 }
 
 type orderService struct {
 	repo       model.OrderRepository
+	promoCodes model.PromoCodeRepository
+	catalog    model.ProductCatalog
 	dispatcher EventDispatcher
 }
 
@@ -68,7 +99,16 @@ func (o orderService) CreateOrder(customerID uuid.UUID) (uuid.UUID, error) {
 }
 
 func (o orderService) DeleteOrder(orderID uuid.UUID) error {
-	err := o.repo.Delete(orderID)
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return ErrInvalidOrderStatus
+	}
+
+	err = o.repo.Delete(order)
 	if err != nil {
 		return err
 	}
@@ -82,19 +122,155 @@ func (o orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) err
 		return err
 	}
 
+	if order.Status == status {
+		return nil
+	}
+	if !order.Status.CanTransitTo(status) {
+		return model.StatusTransitionError{
+			From: order.Status,
+			To:   status,
+		}
+	}
+
+	oldStatus := order.Status
 	order.Status = status
+	order.UpdatedAt = time.Now()
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderStatusChanged{
+		OrderID:   orderID,
+		OldStatus: oldStatus,
+		NewStatus: status,
+	})
+}
+
+func (o orderService) CancelOrder(orderID uuid.UUID, reason string, cancelledBy string) error {
+	if reason == "" || cancelledBy == "" {
+		return model.ErrInvalidCancellation
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status == model.Cancelled {
+		return nil
+	}
+	if !order.Status.CanTransitTo(model.Cancelled) {
+		return model.StatusTransitionError{
+			From: order.Status,
+			To:   model.Cancelled,
+		}
+	}
+
+	oldStatus := order.Status
+	currentTime := time.Now()
+	order.Status = model.Cancelled
+	order.Cancellation = &model.Cancellation{
+		Reason:      reason,
+		CancelledBy: cancelledBy,
+		CancelledAt: currentTime,
+	}
+	order.UpdatedAt = currentTime
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	err = o.dispatcher.Dispatch(model.OrderStatusChanged{
+		OrderID:   orderID,
+		OldStatus: oldStatus,
+		NewStatus: model.Cancelled,
+	})
+	if err != nil {
+		return err
+	}
+	return o.dispatcher.Dispatch(model.OrderCancelled{
+		OrderID:     orderID,
+		Reason:      reason,
+		CancelledBy: cancelledBy,
+	})
+}
+
+func (o orderService) ConfirmPayment(orderID uuid.UUID) error {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Pending {
+		return nil
+	}
+
+	order.Status = model.Paid
+	order.PaymentFailureReason = ""
+	order.UpdatedAt = time.Now()
 	err = o.repo.Store(order)
 	if err != nil {
 		return err
 	}
 
 	return o.dispatcher.Dispatch(model.OrderStatusChanged{
+		OrderID:   orderID,
+		OldStatus: model.Pending,
+		NewStatus: model.Paid,
+	})
+}
+
+func (o orderService) RejectPayment(orderID uuid.UUID, reason string) error {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Pending {
+		return nil
+	}
+
+	order.Status = model.Open
+	order.PaymentFailureReason = reason
+	order.UpdatedAt = time.Now()
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	err = o.dispatcher.Dispatch(model.OrderStatusChanged{
+		OrderID:   orderID,
+		OldStatus: model.Pending,
+		NewStatus: model.Open,
+	})
+	if err != nil {
+		return err
+	}
+	return o.dispatcher.Dispatch(model.OrderPaymentFailed{
 		OrderID: orderID,
-		Status:  status,
+		Reason:  reason,
 	})
 }
 
-func (o orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (uuid.UUID, error) {
+func (o orderService) ExpirePendingOrder(orderID uuid.UUID, pendingBefore time.Time) error {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	// order can not be modified while Pending, so UpdatedAt is the moment it became Pending
+	if order.Status != model.Pending || !order.UpdatedAt.Before(pendingBefore) {
+		return nil
+	}
+	return o.CancelOrder(orderID, model.PendingExpiredReason, model.SystemActor)
+}
+
+func (o orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, variantID uuid.UUID, quantity int) (uuid.UUID, error) {
+	if quantity <= 0 {
+		return uuid.Nil, model.ErrInvalidQuantity
+	}
+
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return uuid.Nil, err
@@ -104,15 +280,43 @@ func (o orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price floa
 		return uuid.Nil, ErrInvalidOrderStatus
 	}
 
-	itemID, err := o.repo.NextID()
+	product, err := o.catalog.FindProduct(productID, variantID)
 	if err != nil {
 		return uuid.Nil, err
 	}
-	order.Items = append(order.Items, model.Item{
-		ID:        itemID,
-		ProductID: productID,
-		Price:     price,
-	})
+	price := product.Price
+	if price.Amount < 0 {
+		return uuid.Nil, model.ErrNegativeAmount
+	}
+	if _, err = order.Subtotal().Add(price); err != nil {
+		return uuid.Nil, err
+	}
+
+	var itemID uuid.UUID
+	for i, item := range order.Items {
+		if item.ProductID == productID && item.VariantID == variantID {
+			itemID = item.ID
+			order.Items[i].Quantity += quantity
+			order.Items[i].ProductName = product.Name
+			order.Items[i].Price = price
+			break
+		}
+	}
+	if itemID == uuid.Nil {
+		itemID, err = o.repo.NextID()
+		if err != nil {
+			return uuid.Nil, err
+		}
+		order.Items = append(order.Items, model.Item{
+			ID:          itemID,
+			ProductID:   productID,
+			VariantID:   variantID,
+			ProductName: product.Name,
+			Quantity:    quantity,
+			Price:       price,
+		})
+	}
+	order.UpdatedAt = time.Now()
 	err = o.repo.Store(order)
 	if err != nil {
 		return uuid.Nil, err
@@ -121,6 +325,7 @@ func (o orderService) AddItem(orderID uuid.UUID, productID uuid.UUID, price floa
 	return itemID, o.dispatcher.Dispatch(model.OrderItemChanged{
 		OrderID:    orderID,
 		AddedItems: []uuid.UUID{itemID},
+		Total:      order.Total(),
 	})
 }
 
@@ -130,10 +335,19 @@ func (o orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
 		return err
 	}
 
-	order.Items = util.Filter(order.Items, func(item model.Item) bool {
+	if order.Status != model.Open {
+		return ErrInvalidOrderStatus
+	}
+
+	items := util.Filter(order.Items, func(item model.Item) bool {
 		return item.ID != itemID
 	})
+	if len(items) == len(order.Items) {
+		return model.ErrOrderItemNotFound
+	}
 
+	order.Items = items
+	order.UpdatedAt = time.Now()
 	err = o.repo.Store(order)
 	if err != nil {
 		return err
@@ -142,5 +356,148 @@ func (o orderService) DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error {
 	return o.dispatcher.Dispatch(model.OrderItemRemoved{
 		OrderID: orderID,
 		ItemID:  itemID,
+		Total:   order.Total(),
 	})
 }
+
+func (o orderService) RemoveProductItems(orderID uuid.UUID, productID uuid.UUID) error {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return nil
+	}
+
+	var removedItems []uuid.UUID
+	order.Items = util.Filter(order.Items, func(item model.Item) bool {
+		if item.ProductID == productID {
+			removedItems = append(removedItems, item.ID)
+			return false
+		}
+		return true
+	})
+	if len(removedItems) == 0 {
+		return nil
+	}
+
+	order.UpdatedAt = time.Now()
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderItemChanged{
+		OrderID:      orderID,
+		RemovedItems: removedItems,
+		Total:        order.Total(),
+	})
+}
+
+func (o orderService) RefreshProductItems(orderID uuid.UUID, product model.Product) error {
+	if product.Price.Amount < 0 {
+		return model.ErrNegativeAmount
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return nil
+	}
+
+	var changedItems []uuid.UUID
+	for i, item := range order.Items {
+		// name and price of variant differ from product ones, variant items are checked against catalog at checkout
+		if item.ProductID != product.ID || item.VariantID != uuid.Nil {
+			// items of order must share one currency
+			if _, err = item.Price.Add(product.Price); err != nil {
+				return err
+			}
+			continue
+		}
+		if item.ProductName == product.Name && item.Price == product.Price {
+			continue
+		}
+		changedItems = append(changedItems, item.ID)
+		order.Items[i].ProductName = product.Name
+		order.Items[i].Price = product.Price
+	}
+	if len(changedItems) == 0 {
+		return nil
+	}
+
+	order.UpdatedAt = time.Now()
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderItemChanged{
+		OrderID:      orderID,
+		ChangedItems: changedItems,
+		Total:        order.Total(),
+	})
+}
+
+func (o orderService) ApplyPromoCode(orderID uuid.UUID, code string) error {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return ErrInvalidOrderStatus
+	}
+	if order.PromoCode != nil && order.PromoCode.Code == code {
+		return nil
+	}
+
+	promoCode, err := o.promoCodes.Find(code)
+	if err != nil {
+		return err
+	}
+	currentTime := time.Now()
+	if !promoCode.IsActive(currentTime) {
+		return model.ErrPromoCodeNotActive
+	}
+	if promoCode.UsageLimit > 0 {
+		var usages int
+		usages, err = o.promoCodes.CountUsages(code, order.CustomerID, orderID)
+		if err != nil {
+			return err
+		}
+		if usages >= promoCode.UsageLimit {
+			return model.ErrPromoCodeUsageLimitExceeded
+		}
+	}
+	if promoCode.Rule.Type == model.FixedDiscount && len(order.Items) > 0 {
+		if _, err = order.Subtotal().Add(promoCode.Rule.Amount); err != nil {
+			return err
+		}
+	}
+
+	order.PromoCode = &model.AppliedPromoCode{
+		Code: promoCode.Code,
+		Rule: promoCode.Rule,
+	}
+	order.UpdatedAt = currentTime
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderPromoCodeApplied{
+		OrderID:  orderID,
+		Code:     promoCode.Code,
+		Discount: order.Discount(),
+		Total:    order.Total(),
+	})
+}
+
+func (o orderService) GetOrder(orderID uuid.UUID) (*model.Order, error) {
+	return o.repo.Find(orderID)
+}
@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("Add", func(t *testing.T) {
+		a := model.Money{Amount: 1010, Currency: model.DefaultCurrency}
+		b := model.Money{Amount: 2020, Currency: model.DefaultCurrency}
+
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 3030, Currency: model.DefaultCurrency}, sum)
+
+		sum, err = model.Money{}.Add(a)
+		require.NoError(t, err)
+		require.Equal(t, a, sum)
+	})
+
+	t.Run("Add_FailsOnCurrencyMismatch", func(t *testing.T) {
+		a := model.Money{Amount: 100, Currency: "RUB"}
+		b := model.Money{Amount: 100, Currency: "USD"}
+
+		_, err := a.Add(b)
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+	})
+
+	t.Run("Multiply", func(t *testing.T) {
+		m := model.Money{Amount: 333, Currency: model.DefaultCurrency}
+		require.Equal(t, model.Money{Amount: 999, Currency: model.DefaultCurrency}, m.Multiply(3))
+	})
+
+	t.Run("NewMoney_FailsOnNegativeAmount", func(t *testing.T) {
+		_, err := model.NewMoney(-1, model.DefaultCurrency)
+		require.ErrorIs(t, err, model.ErrNegativeAmount)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "12.05 RUB", model.Money{Amount: 1205, Currency: "RUB"}.String())
+	})
+}
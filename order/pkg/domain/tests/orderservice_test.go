@@ -11,11 +11,32 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestOrderStatusTransitions(t *testing.T) {
+	allowed := map[model.OrderStatus][]model.OrderStatus{
+		model.Open:      {model.Pending, model.Cancelled},
+		model.Pending:   {model.Open, model.Paid, model.Cancelled},
+		model.Paid:      {},
+		model.Cancelled: {},
+	}
+	statuses := []model.OrderStatus{model.Open, model.Pending, model.Paid, model.Cancelled}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			expected := false
+			for _, s := range allowed[from] {
+				expected = expected || s == to
+			}
+			require.Equal(t, expected, from.CanTransitTo(to), "%s -> %s", from, to)
+		}
+	}
+}
+
 func TestOrderService(t *testing.T) {
 	t.Run("CreateOrder", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
@@ -36,8 +57,9 @@ func TestOrderService(t *testing.T) {
 
 	t.Run("DeleteOrder", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
@@ -59,8 +81,9 @@ func TestOrderService(t *testing.T) {
 
 	t.Run("SetStatus", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
@@ -78,22 +101,234 @@ func TestOrderService(t *testing.T) {
 		event, ok := dispatcher.events[1].(model.OrderStatusChanged)
 		require.True(t, ok)
 		require.Equal(t, orderID, event.OrderID)
-		require.Equal(t, newStatus, event.Status)
+		require.Equal(t, model.Open, event.OldStatus)
+		require.Equal(t, newStatus, event.NewStatus)
+	})
+
+	t.Run("SetStatus_SameStatusIsNoop", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		err = svc.SetStatus(orderID, model.Open)
+		require.NoError(t, err)
+		require.Len(t, dispatcher.events, 1)
+	})
+
+	t.Run("SetStatus_FailsOnIllegalTransition", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		err = svc.SetStatus(orderID, model.Cancelled)
+		require.NoError(t, err)
+
+		err = svc.SetStatus(orderID, model.Open)
+		require.ErrorIs(t, err, model.ErrInvalidStatusTransition)
+		var transitionErr model.StatusTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		require.Equal(t, model.Cancelled, transitionErr.From)
+		require.Equal(t, model.Open, transitionErr.To)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, stored.Status)
+		require.Len(t, dispatcher.events, 2)
+	})
+
+	t.Run("CancelOrder", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		err = svc.CancelOrder(orderID, "changed my mind", customerID.String())
+		require.NoError(t, err)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, stored.Status)
+		require.NotNil(t, stored.Cancellation)
+		require.Equal(t, "changed my mind", stored.Cancellation.Reason)
+		require.Equal(t, customerID.String(), stored.Cancellation.CancelledBy)
+
+		require.Len(t, dispatcher.events, 3)
+		statusEvent, ok := dispatcher.events[1].(model.OrderStatusChanged)
+		require.True(t, ok)
+		require.Equal(t, model.Cancelled, statusEvent.NewStatus)
+		event, ok := dispatcher.events[2].(model.OrderCancelled)
+		require.True(t, ok)
+		require.Equal(t, orderID, event.OrderID)
+		require.Equal(t, "changed my mind", event.Reason)
+		require.Equal(t, customerID.String(), event.CancelledBy)
+
+		// repeated cancellation is a no-op
+		err = svc.CancelOrder(orderID, "another reason", model.SystemActor)
+		require.NoError(t, err)
+		require.Len(t, dispatcher.events, 3)
+		require.Equal(t, "changed my mind", stored.Cancellation.Reason)
+	})
+
+	t.Run("CancelOrder_FailsWithoutReasonOrForPaidOrder", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		err = svc.CancelOrder(orderID, "", customerID.String())
+		require.ErrorIs(t, err, model.ErrInvalidCancellation)
+
+		require.NoError(t, svc.SetStatus(orderID, model.Pending))
+		require.NoError(t, svc.SetStatus(orderID, model.Paid))
+
+		err = svc.CancelOrder(orderID, "too late", customerID.String())
+		require.ErrorIs(t, err, model.ErrInvalidStatusTransition)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Paid, stored.Status)
+		require.Nil(t, stored.Cancellation)
+	})
+
+	t.Run("ExpirePendingOrder", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		pendingBefore := time.Now().Add(-30 * time.Minute)
+
+		staleOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, svc.SetStatus(staleOrderID, model.Pending))
+		repo.store[staleOrderID].UpdatedAt = pendingBefore.Add(-time.Minute)
+
+		freshOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, svc.SetStatus(freshOrderID, model.Pending))
+
+		openOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		repo.store[openOrderID].UpdatedAt = pendingBefore.Add(-time.Minute)
+
+		for _, orderID := range []uuid.UUID{staleOrderID, freshOrderID, openOrderID} {
+			require.NoError(t, svc.ExpirePendingOrder(orderID, pendingBefore))
+		}
+
+		stale, err := repo.Find(staleOrderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, stale.Status)
+		require.Equal(t, model.PendingExpiredReason, stale.Cancellation.Reason)
+		require.Equal(t, model.SystemActor, stale.Cancellation.CancelledBy)
+
+		fresh, err := repo.Find(freshOrderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Pending, fresh.Status)
+
+		open, err := repo.Find(openOrderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Open, open.Status)
+
+		event, ok := dispatcher.events[len(dispatcher.events)-1].(model.OrderCancelled)
+		require.True(t, ok)
+		require.Equal(t, staleOrderID, event.OrderID)
+	})
+
+	t.Run("RejectPayment_ThenConfirmPayment", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		orderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, svc.SetStatus(orderID, model.Pending))
+
+		err = svc.RejectPayment(orderID, "insufficient funds")
+		require.NoError(t, err)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Open, stored.Status)
+		require.Equal(t, "insufficient funds", stored.PaymentFailureReason)
+
+		require.Len(t, dispatcher.events, 4)
+		statusEvent, ok := dispatcher.events[2].(model.OrderStatusChanged)
+		require.True(t, ok)
+		require.Equal(t, model.Pending, statusEvent.OldStatus)
+		require.Equal(t, model.Open, statusEvent.NewStatus)
+		event, ok := dispatcher.events[3].(model.OrderPaymentFailed)
+		require.True(t, ok)
+		require.Equal(t, orderID, event.OrderID)
+		require.Equal(t, "insufficient funds", event.Reason)
+
+		require.NoError(t, svc.SetStatus(orderID, model.Pending))
+		err = svc.ConfirmPayment(orderID)
+		require.NoError(t, err)
+
+		stored, err = repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Paid, stored.Status)
+		require.Empty(t, stored.PaymentFailureReason)
+
+		require.Len(t, dispatcher.events, 6)
+		statusEvent, ok = dispatcher.events[5].(model.OrderStatusChanged)
+		require.True(t, ok)
+		require.Equal(t, model.Paid, statusEvent.NewStatus)
+	})
+
+	t.Run("PaymentEvents_IgnoredForNotPendingOrder", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		orderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, svc.CancelOrder(orderID, "changed my mind", model.SystemActor))
+
+		require.NoError(t, svc.ConfirmPayment(orderID))
+		require.NoError(t, svc.RejectPayment(orderID, "insufficient funds"))
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, stored.Status)
+		require.Empty(t, stored.PaymentFailureReason)
+		require.Len(t, dispatcher.events, 3)
 	})
 
 	t.Run("AddItem", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
 		require.NoError(t, err)
 
-		productID := uuid.Must(uuid.NewV7())
-		price := 199.99
+		price := model.Money{Amount: 19999, Currency: model.DefaultCurrency}
+		productID := catalog.add("Keyboard", price)
 
-		itemID, err := svc.AddItem(orderID, productID, price)
+		itemID, err := svc.AddItem(orderID, productID, uuid.Nil, 2)
 		require.NoError(t, err)
 		require.NotEqual(t, uuid.Nil, itemID)
 
@@ -103,6 +338,8 @@ func TestOrderService(t *testing.T) {
 		item := stored.Items[0]
 		require.Equal(t, itemID, item.ID)
 		require.Equal(t, productID, item.ProductID)
+		require.Equal(t, "Keyboard", item.ProductName)
+		require.Equal(t, 2, item.Quantity)
 		require.Equal(t, price, item.Price)
 
 		require.Len(t, dispatcher.events, 2)
@@ -110,12 +347,249 @@ func TestOrderService(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, orderID, event.OrderID)
 		require.Equal(t, []uuid.UUID{itemID}, event.AddedItems)
+		require.Equal(t, model.Money{Amount: 39998, Currency: model.DefaultCurrency}, event.Total)
+	})
+
+	t.Run("RemoveProductItems", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		keyboardID := catalog.add("Keyboard", model.Money{Amount: 19999, Currency: model.DefaultCurrency})
+		mouseID := catalog.add("Mouse", model.Money{Amount: 4999, Currency: model.DefaultCurrency})
+
+		openOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		keyboardItemID, err := svc.AddItem(openOrderID, keyboardID, uuid.Nil, 1)
+		require.NoError(t, err)
+		_, err = svc.AddItem(openOrderID, mouseID, uuid.Nil, 2)
+		require.NoError(t, err)
+
+		pendingOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		_, err = svc.AddItem(pendingOrderID, keyboardID, uuid.Nil, 1)
+		require.NoError(t, err)
+		require.NoError(t, svc.SetStatus(pendingOrderID, model.Pending))
+
+		eventsCount := len(dispatcher.events)
+		require.NoError(t, svc.RemoveProductItems(openOrderID, keyboardID))
+		require.NoError(t, svc.RemoveProductItems(pendingOrderID, keyboardID))
+
+		open, err := repo.Find(openOrderID)
+		require.NoError(t, err)
+		require.Len(t, open.Items, 1)
+		require.Equal(t, mouseID, open.Items[0].ProductID)
+
+		pending, err := repo.Find(pendingOrderID)
+		require.NoError(t, err)
+		require.Len(t, pending.Items, 1)
+
+		require.Len(t, dispatcher.events, eventsCount+1)
+		event, ok := dispatcher.events[eventsCount].(model.OrderItemChanged)
+		require.True(t, ok)
+		require.Equal(t, openOrderID, event.OrderID)
+		require.Equal(t, []uuid.UUID{keyboardItemID}, event.RemovedItems)
+		require.Equal(t, model.Money{Amount: 9998, Currency: model.DefaultCurrency}, event.Total)
+
+		// repeated removal is a no-op
+		require.NoError(t, svc.RemoveProductItems(openOrderID, keyboardID))
+		require.Len(t, dispatcher.events, eventsCount+1)
+	})
+
+	t.Run("RefreshProductItems", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		keyboardID := catalog.add("Keyboard", model.Money{Amount: 19999, Currency: model.DefaultCurrency})
+
+		orderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		itemID, err := svc.AddItem(orderID, keyboardID, uuid.Nil, 2)
+		require.NoError(t, err)
+
+		product := model.Product{
+			ID:    keyboardID,
+			Name:  "Mechanical keyboard",
+			Price: model.Money{Amount: 14999, Currency: model.DefaultCurrency},
+		}
+		eventsCount := len(dispatcher.events)
+		require.NoError(t, svc.RefreshProductItems(orderID, product))
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 1)
+		require.Equal(t, "Mechanical keyboard", stored.Items[0].ProductName)
+		require.Equal(t, product.Price, stored.Items[0].Price)
+		require.Equal(t, 2, stored.Items[0].Quantity)
+
+		require.Len(t, dispatcher.events, eventsCount+1)
+		event, ok := dispatcher.events[eventsCount].(model.OrderItemChanged)
+		require.True(t, ok)
+		require.Equal(t, []uuid.UUID{itemID}, event.ChangedItems)
+		require.Equal(t, model.Money{Amount: 29998, Currency: model.DefaultCurrency}, event.Total)
+
+		// unchanged snapshot is not stored again
+		require.NoError(t, svc.RefreshProductItems(orderID, product))
+		require.Len(t, dispatcher.events, eventsCount+1)
+	})
+
+	t.Run("AddItem_IncreasesQuantityOfAlreadyAddedProduct", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		productID := catalog.add("Mouse", model.Money{Amount: 1000, Currency: model.DefaultCurrency})
+		firstItemID, err := svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+
+		catalog.products[productID] = model.Product{
+			ID:    productID,
+			Name:  "Wireless mouse",
+			Price: model.Money{Amount: 1050, Currency: model.DefaultCurrency},
+		}
+		secondItemID, err := svc.AddItem(orderID, productID, uuid.Nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, firstItemID, secondItemID)
+
+		otherProductID := catalog.add("Pad", model.Money{Amount: 300, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, otherProductID, uuid.Nil, 1)
+		require.NoError(t, err)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 2)
+		require.Equal(t, 3, stored.Items[0].Quantity)
+		require.Equal(t, "Wireless mouse", stored.Items[0].ProductName)
+		require.Equal(t, model.Money{Amount: 3150, Currency: model.DefaultCurrency}, stored.Items[0].Subtotal())
+		require.Equal(t, model.Money{Amount: 3450, Currency: model.DefaultCurrency}, stored.Total())
+
+		event, ok := dispatcher.events[len(dispatcher.events)-1].(model.OrderItemChanged)
+		require.True(t, ok)
+		require.Equal(t, stored.Total(), event.Total)
+	})
+
+	t.Run("AddItem_KeepsVariantsOfProductInSeparateItems", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		productID := catalog.add("T-shirt", model.Money{Amount: 2000, Currency: model.DefaultCurrency})
+		variantID := catalog.addVariant(productID, "T-shirt (size: XL)", model.Money{Amount: 2500, Currency: model.DefaultCurrency})
+		productItemID, err := svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+		variantItemID, err := svc.AddItem(orderID, productID, variantID, 1)
+		require.NoError(t, err)
+		require.NotEqual(t, productItemID, variantItemID)
+		secondVariantItemID, err := svc.AddItem(orderID, productID, variantID, 1)
+		require.NoError(t, err)
+		require.Equal(t, variantItemID, secondVariantItemID)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 2)
+		require.Equal(t, variantID, stored.Items[1].VariantID)
+		require.Equal(t, "T-shirt (size: XL)", stored.Items[1].ProductName)
+		require.Equal(t, model.Money{Amount: 7000, Currency: model.DefaultCurrency}, stored.Total())
+
+		// variant snapshot is not overwritten by product one
+		require.NoError(t, svc.RefreshProductItems(orderID, model.Product{
+			ID:    productID,
+			Name:  "Cotton T-shirt",
+			Price: model.Money{Amount: 2200, Currency: model.DefaultCurrency},
+		}))
+		stored, err = repo.Find(orderID)
+		require.NoError(t, err)
+		require.Equal(t, "Cotton T-shirt", stored.Items[0].ProductName)
+		require.Equal(t, "T-shirt (size: XL)", stored.Items[1].ProductName)
+
+		// variant must belong to added product
+		_, err = svc.AddItem(orderID, uuid.Must(uuid.NewV7()), variantID, 1)
+		require.ErrorIs(t, err, model.ErrProductNotFound)
+	})
+
+	t.Run("AddItem_FailsOnInvalidQuantityOrPrice", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		productID := catalog.add("Cable", model.Money{Amount: 100, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 0)
+		require.ErrorIs(t, err, model.ErrInvalidQuantity)
+
+		brokenProductID := catalog.add("Broken", model.Money{Amount: -1, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, brokenProductID, uuid.Nil, 1)
+		require.ErrorIs(t, err, model.ErrNegativeAmount)
+
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+		foreignProductID := catalog.add("Adapter", model.Money{Amount: 100, Currency: "USD"})
+		_, err = svc.AddItem(orderID, foreignProductID, uuid.Nil, 1)
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 1)
+	})
+
+	t.Run("AddItem_FailsOnUnknownProduct", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		_, err = svc.AddItem(orderID, uuid.Must(uuid.NewV7()), uuid.Nil, 1)
+		require.ErrorIs(t, err, model.ErrProductNotFound)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Empty(t, stored.Items)
+		require.Len(t, dispatcher.events, 1)
+	})
+
+	t.Run("AddItem_FailsOnVersionConflict", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		repo.err = model.ErrOrderVersionConflict
+		productID := catalog.add("Charger", model.Money{Amount: 2500, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.ErrorIs(t, err, model.ErrOrderVersionConflict)
+		require.Len(t, dispatcher.events, 1)
 	})
 
 	t.Run("AddItem_FailsWhenOrderNotOpen", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
@@ -124,23 +598,24 @@ func TestOrderService(t *testing.T) {
 		err = svc.SetStatus(orderID, model.Cancelled)
 		require.NoError(t, err)
 
-		productID := uuid.Must(uuid.NewV7())
-		_, err = svc.AddItem(orderID, productID, 99.99)
+		productID := catalog.add("Monitor", model.Money{Amount: 9999, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
 		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
 		require.Len(t, dispatcher.events, 2)
 	})
 
 	t.Run("DeleteItem", func(t *testing.T) {
 		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewOrderService(repo, dispatcher)
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
 
 		customerID := uuid.Must(uuid.NewV7())
 		orderID, err := svc.CreateOrder(customerID)
 		require.NoError(t, err)
 
-		productID := uuid.Must(uuid.NewV7())
-		itemID, err := svc.AddItem(orderID, productID, 50.0)
+		productID := catalog.add("Headphones", model.Money{Amount: 5000, Currency: model.DefaultCurrency})
+		itemID, err := svc.AddItem(orderID, productID, uuid.Nil, 1)
 		require.NoError(t, err)
 
 		err = svc.DeleteItem(orderID, itemID)
@@ -155,13 +630,124 @@ func TestOrderService(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, orderID, event.OrderID)
 		require.Equal(t, itemID, event.ItemID)
+		require.True(t, event.Total.IsZero())
+	})
+
+	t.Run("DeleteItem_FailsOnUnknownItem", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		productID := catalog.add("Speaker", model.Money{Amount: 7000, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+
+		err = svc.DeleteItem(orderID, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, model.ErrOrderItemNotFound)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 1)
+		require.Len(t, dispatcher.events, 2)
 	})
+
+	t.Run("DeleteItem_FailsWhenOrderNotOpen", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		productID := catalog.add("Speaker", model.Money{Amount: 7000, Currency: model.DefaultCurrency})
+		itemID, err := svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+
+		for _, status := range []model.OrderStatus{model.Pending, model.Paid} {
+			require.NoError(t, svc.SetStatus(orderID, status))
+
+			err = svc.DeleteItem(orderID, itemID)
+			require.ErrorIs(t, err, service.ErrInvalidOrderStatus, status.String())
+		}
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Len(t, stored.Items, 1)
+		require.Len(t, dispatcher.events, 4)
+	})
+
+	t.Run("DeleteOrder_FailsWhenOrderNotOpen", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.CancelOrder(orderID, "not needed", customerID.String()))
+
+		err = svc.DeleteOrder(orderID)
+		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.Nil(t, stored.DeletedAt)
+		require.Len(t, dispatcher.events, 3)
+	})
+
+	t.Run("DeleteOrder_FailsOnConcurrentModification", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		repo.err = model.ErrOrderVersionConflict
+		err = svc.DeleteOrder(orderID)
+		require.ErrorIs(t, err, model.ErrOrderVersionConflict)
+		require.Nil(t, repo.store[orderID].DeletedAt)
+		require.Len(t, dispatcher.events, 1)
+	})
+
+	t.Run("GetOrder", func(t *testing.T) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewOrderService(repo, &mockPromoCodeRepository{orders: repo}, catalog, dispatcher)
+
+		customerID := uuid.Must(uuid.NewV7())
+		orderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+
+		order, err := svc.GetOrder(orderID)
+		require.NoError(t, err)
+		require.Equal(t, orderID, order.ID)
+		require.Equal(t, customerID, order.CustomerID)
+
+		_, err = svc.GetOrder(uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
+
 }
 
 var _ model.OrderRepository = &mockOrderRepository{}
 
 type mockOrderRepository struct {
 	store map[uuid.UUID]*model.Order
+	// err is returned by Store when set, e.g. to simulate concurrent modification
+	err error
 }
 
 func (m *mockOrderRepository) NextID() (uuid.UUID, error) {
@@ -169,6 +755,9 @@ func (m *mockOrderRepository) NextID() (uuid.UUID, error) {
 }
 
 func (m *mockOrderRepository) Store(order *model.Order) error {
+	if m.err != nil {
+		return m.err
+	}
 	m.store[order.ID] = order
 	return nil
 }
@@ -180,14 +769,88 @@ func (m *mockOrderRepository) Find(id uuid.UUID) (*model.Order, error) {
 	return nil, model.ErrOrderNotFound
 }
 
-func (m *mockOrderRepository) Delete(id uuid.UUID) error {
-	if order, ok := m.store[id]; ok && order.DeletedAt == nil {
+func (m *mockOrderRepository) Delete(order *model.Order) error {
+	if m.err != nil {
+		return m.err
+	}
+	if order, ok := m.store[order.ID]; ok && order.DeletedAt == nil {
 		order.DeletedAt = toPtr(time.Now())
 		return nil
 	}
 	return model.ErrOrderNotFound
 }
 
+var _ model.ProductCatalog = &mockProductCatalog{}
+
+type mockProductCatalog struct {
+	products map[uuid.UUID]model.Product
+	// variants are keyed by variant id
+	variants map[uuid.UUID]model.Product
+}
+
+func (m *mockProductCatalog) add(name string, price model.Money) uuid.UUID {
+	id := uuid.Must(uuid.NewV7())
+	m.products[id] = model.Product{
+		ID:    id,
+		Name:  name,
+		Price: price,
+	}
+	return id
+}
+
+func (m *mockProductCatalog) addVariant(productID uuid.UUID, name string, price model.Money) uuid.UUID {
+	if m.variants == nil {
+		m.variants = make(map[uuid.UUID]model.Product)
+	}
+	id := uuid.Must(uuid.NewV7())
+	m.variants[id] = model.Product{
+		ID:        productID,
+		VariantID: id,
+		Name:      name,
+		Price:     price,
+	}
+	return id
+}
+
+func (m *mockProductCatalog) FindProduct(id uuid.UUID, variantID uuid.UUID) (model.Product, error) {
+	if variantID != uuid.Nil {
+		if variant, ok := m.variants[variantID]; ok && variant.ID == id {
+			return variant, nil
+		}
+		return model.Product{}, model.ErrProductNotFound
+	}
+	if product, ok := m.products[id]; ok {
+		return product, nil
+	}
+	return model.Product{}, model.ErrProductNotFound
+}
+
+var _ model.PromoCodeRepository = &mockPromoCodeRepository{}
+
+type mockPromoCodeRepository struct {
+	codes  map[string]model.PromoCode
+	orders *mockOrderRepository
+}
+
+func (m *mockPromoCodeRepository) Find(code string) (*model.PromoCode, error) {
+	promoCode, ok := m.codes[code]
+	if !ok {
+		return nil, model.ErrPromoCodeNotFound
+	}
+	return &promoCode, nil
+}
+
+func (m *mockPromoCodeRepository) CountUsages(code string, customerID uuid.UUID, excludedOrderID uuid.UUID) (int, error) {
+	count := 0
+	for _, order := range m.orders.store {
+		if order.ID != excludedOrderID && order.CustomerID == customerID && order.Status != model.Cancelled &&
+			order.PromoCode != nil && order.PromoCode.Code == code {
+			count++
+		}
+	}
+	return count, nil
+}
+
 var _ service.EventDispatcher = &mockEventDispatcher{}
 
 type mockEventDispatcher struct {
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestDiscountRule(t *testing.T) {
+	keyboardID := uuid.Must(uuid.NewV7())
+	items := []model.Item{
+		{ProductID: keyboardID, Quantity: 5, Price: model.Money{Amount: 1000, Currency: model.DefaultCurrency}},
+		{ProductID: uuid.Must(uuid.NewV7()), Quantity: 1, Price: model.Money{Amount: 500, Currency: model.DefaultCurrency}},
+	}
+
+	t.Run("Percentage", func(t *testing.T) {
+		rule := model.DiscountRule{Type: model.PercentageDiscount, Percent: 10}
+		require.Equal(t, model.Money{Amount: 550, Currency: model.DefaultCurrency}, rule.Discount(items))
+	})
+
+	t.Run("Fixed_NotGreaterThanSubtotal", func(t *testing.T) {
+		rule := model.DiscountRule{Type: model.FixedDiscount, Amount: model.Money{Amount: 700, Currency: model.DefaultCurrency}}
+		require.Equal(t, model.Money{Amount: 700, Currency: model.DefaultCurrency}, rule.Discount(items))
+
+		rule.Amount.Amount = 10000
+		require.Equal(t, model.Money{Amount: 5500, Currency: model.DefaultCurrency}, rule.Discount(items))
+	})
+
+	t.Run("BuyNGetOneFree", func(t *testing.T) {
+		// 5 units with every third one free
+		rule := model.DiscountRule{Type: model.BuyNGetOneFreeDiscount, ProductID: keyboardID, BuyQuantity: 2}
+		require.Equal(t, model.Money{Amount: 1000, Currency: model.DefaultCurrency}, rule.Discount(items))
+	})
+}
+
+func TestApplyPromoCode(t *testing.T) {
+	validFrom := time.Now().Add(-time.Hour)
+	newService := func() (service.Order, *mockOrderRepository, *mockProductCatalog, *mockEventDispatcher) {
+		repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+		promoCodes := &mockPromoCodeRepository{
+			codes: map[string]model.PromoCode{
+				"SALE10": {
+					Code:       "SALE10",
+					Rule:       model.DiscountRule{Type: model.PercentageDiscount, Percent: 10},
+					ValidFrom:  validFrom,
+					UsageLimit: 1,
+				},
+				"EXPIRED": {
+					Code:      "EXPIRED",
+					Rule:      model.DiscountRule{Type: model.PercentageDiscount, Percent: 50},
+					ValidFrom: validFrom,
+					ValidTo:   toPtr(validFrom.Add(time.Minute)),
+				},
+			},
+			orders: repo,
+		}
+		catalog := &mockProductCatalog{products: make(map[uuid.UUID]model.Product)}
+		dispatcher := &mockEventDispatcher{}
+		return service.NewOrderService(repo, promoCodes, catalog, dispatcher), repo, catalog, dispatcher
+	}
+
+	t.Run("AppliesDiscountToTotal", func(t *testing.T) {
+		svc, repo, catalog, dispatcher := newService()
+
+		orderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		productID := catalog.add("Keyboard", model.Money{Amount: 20000, Currency: model.DefaultCurrency})
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.ApplyPromoCode(orderID, "SALE10"))
+
+		stored, err := repo.Find(orderID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.PromoCode)
+		require.Equal(t, "SALE10", stored.PromoCode.Code)
+		require.Equal(t, model.Money{Amount: 18000, Currency: model.DefaultCurrency}, stored.Total())
+
+		event, ok := dispatcher.events[len(dispatcher.events)-1].(model.OrderPromoCodeApplied)
+		require.True(t, ok)
+		require.Equal(t, model.Money{Amount: 2000, Currency: model.DefaultCurrency}, event.Discount)
+		require.Equal(t, model.Money{Amount: 18000, Currency: model.DefaultCurrency}, event.Total)
+
+		// discount follows items
+		_, err = svc.AddItem(orderID, productID, uuid.Nil, 1)
+		require.NoError(t, err)
+		itemEvent, ok := dispatcher.events[len(dispatcher.events)-1].(model.OrderItemChanged)
+		require.True(t, ok)
+		require.Equal(t, model.Money{Amount: 36000, Currency: model.DefaultCurrency}, itemEvent.Total)
+	})
+
+	t.Run("FailsForInactiveOrUnknownCode", func(t *testing.T) {
+		svc, _, _, _ := newService()
+
+		orderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, svc.ApplyPromoCode(orderID, "EXPIRED"), model.ErrPromoCodeNotActive)
+		require.ErrorIs(t, svc.ApplyPromoCode(orderID, "UNKNOWN"), model.ErrPromoCodeNotFound)
+	})
+
+	t.Run("FailsWhenCustomerUsageLimitExceeded", func(t *testing.T) {
+		svc, _, _, _ := newService()
+
+		customerID := uuid.Must(uuid.NewV7())
+		firstOrderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+		require.NoError(t, svc.ApplyPromoCode(firstOrderID, "SALE10"))
+		// repeated application to the same order is not another usage
+		require.NoError(t, svc.ApplyPromoCode(firstOrderID, "SALE10"))
+
+		secondOrderID, err := svc.CreateOrder(customerID)
+		require.NoError(t, err)
+		require.ErrorIs(t, svc.ApplyPromoCode(secondOrderID, "SALE10"), model.ErrPromoCodeUsageLimitExceeded)
+
+		// cancelled order frees the usage
+		require.NoError(t, svc.CancelOrder(firstOrderID, "changed my mind", customerID.String()))
+		require.NoError(t, svc.ApplyPromoCode(secondOrderID, "SALE10"))
+
+		otherCustomerOrderID, err := svc.CreateOrder(uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.NoError(t, svc.ApplyPromoCode(otherCustomerOrderID, "SALE10"))
+	})
+}
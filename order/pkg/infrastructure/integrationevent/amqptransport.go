@@ -0,0 +1,195 @@
+package integrationevent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"github.com/google/uuid"
+
+	"order/pkg/application/query"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+const (
+	// PaymentEventsQueueName collects events of payment service consumed by order service
+	PaymentEventsQueueName  = "order_payment_events"
+	PaymentRoutingKeyPrefix = "payment."
+	// ProductEventsQueueName collects events of product service consumed by order service
+	ProductEventsQueueName  = "order_product_events"
+	ProductRoutingKeyPrefix = "product."
+)
+
+var errUnhandledDelivery = errors.New("unhandled delivery")
+
+func NewAMQPTransport(
+	logger logging.Logger,
+	orderService service.OrderService,
+	orderQueryService query.OrderQueryService,
+) AMQPTransport {
+	return &amqpTransport{
+		logger:            logger,
+		orderService:      orderService,
+		orderQueryService: orderQueryService,
+	}
+}
+
+type AMQPTransport interface {
+	Handler() amqp.Handler
+}
+
+type amqpTransport struct {
+	logger            logging.Logger
+	orderService      service.OrderService
+	orderQueryService query.OrderQueryService
+}
+
+func (t *amqpTransport) Handler() amqp.Handler {
+	return t.withLog(t.handle)
+}
+
+func (t *amqpTransport) handle(ctx context.Context, delivery amqp.Delivery) error {
+	ctx = service.WithActor(ctx, model.SystemActor)
+	switch delivery.Type {
+	case "PaymentSucceeded":
+		var e PaymentSucceeded
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		return t.orderService.HandlePaymentSucceeded(ctx, e.TransactionID, e.OrderID)
+	case "PaymentFailed":
+		var e PaymentFailed
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		return t.orderService.HandlePaymentFailed(ctx, e.TransactionID, e.OrderID, e.Reason)
+	case "ProductUpdated":
+		var e ProductUpdated
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		if e.OldName == e.NewName && e.OldPrice == e.NewPrice {
+			return nil
+		}
+		product := model.Product{
+			ID:   e.ProductID,
+			Name: e.NewName,
+			Price: model.Money{
+				// product service still keeps price as float
+				Amount:   int64(math.Round(e.NewPrice * 100)),
+				Currency: model.DefaultCurrency,
+			},
+		}
+		return t.forEachOpenOrderWithProduct(ctx, e.ProductID, func(orderID uuid.UUID) error {
+			return t.orderService.RefreshProductItems(ctx, orderID, product)
+		})
+	case "ProductDeleted":
+		var e ProductDeleted
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			return err
+		}
+		return t.forEachOpenOrderWithProduct(ctx, e.ProductID, func(orderID uuid.UUID) error {
+			return t.orderService.RemoveProductItems(ctx, orderID, e.ProductID)
+		})
+	default:
+		return errUnhandledDelivery
+	}
+}
+
+// forEachOpenOrderWithProduct applies f to Open orders having items of the product,
+// f must be idempotent since failed delivery is redelivered
+func (t *amqpTransport) forEachOpenOrderWithProduct(ctx context.Context, productID uuid.UUID, f func(orderID uuid.UUID) error) error {
+	spec := query.ListOrdersSpec{
+		ProductID: &productID,
+		Statuses:  []model.OrderStatus{model.Open},
+		PageSize:  query.MaxPageSize,
+	}
+	for {
+		page, err := t.orderQueryService.ListOrders(ctx, spec)
+		if err != nil {
+			return err
+		}
+		for _, order := range page.Orders {
+			err = f(order.ID)
+			// order could be deleted after it was listed
+			if err != nil && !errors.Is(err, model.ErrOrderNotFound) {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		spec.Cursor = page.NextCursor
+	}
+}
+
+func (t *amqpTransport) withLog(handler amqp.Handler) amqp.Handler {
+	return func(ctx context.Context, delivery amqp.Delivery) error {
+		l := t.logger.WithFields(logging.Fields{
+			"routing_key":    delivery.RoutingKey,
+			"correlation_id": delivery.CorrelationID,
+			"content_type":   delivery.ContentType,
+		})
+		if delivery.ContentType != ContentType {
+			l.Warning(errors.New("invalid content type"), "skipping")
+			return nil
+		}
+		l = l.WithField("body", json.RawMessage(delivery.Body))
+
+		start := time.Now()
+		err := handler(ctx, delivery)
+		l = l.WithField("duration", time.Since(start))
+
+		if err != nil {
+			if errors.Is(err, errUnhandledDelivery) {
+				l.Info("unhandled delivery, skipping")
+				return nil
+			}
+			// requeueing will not make the order appear
+			if errors.Is(err, model.ErrOrderNotFound) {
+				l.Warning(err, "order not found, skipping")
+				return nil
+			}
+			l.Error(err, "failed to handle message")
+		} else {
+			l.Info("successfully handled message")
+		}
+		return err
+	}
+}
+
+// PaymentSucceeded mirrors event published by payment service
+type PaymentSucceeded struct {
+	TransactionID uuid.UUID `json:"TransactionID"`
+	OrderID       uuid.UUID `json:"OrderID"`
+}
+
+// PaymentFailed mirrors event published by payment service
+type PaymentFailed struct {
+	TransactionID uuid.UUID `json:"TransactionID"`
+	OrderID       uuid.UUID `json:"OrderID"`
+	Reason        string    `json:"Reason"`
+}
+
+// ProductUpdated mirrors event published by product service
+type ProductUpdated struct {
+	ProductID uuid.UUID `json:"ProductID"`
+	OldName   string    `json:"OldName"`
+	NewName   string    `json:"NewName"`
+	OldPrice  float64   `json:"OldPrice"`
+	NewPrice  float64   `json:"NewPrice"`
+}
+
+// ProductDeleted mirrors event published by product service
+type ProductDeleted struct {
+	ProductID uuid.UUID `json:"ProductID"`
+}
@@ -0,0 +1,169 @@
+package integrationevent
+
+import (
+	"encoding/json"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"order/pkg/domain/model"
+)
+
+// EventVersion is bumped on every incompatible change of the payloads below
+const EventVersion = 1
+
+func NewEventSerializer() outbox.EventSerializer[outbox.Event] {
+	return &eventSerializer{}
+}
+
+type eventSerializer struct{}
+
+func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
+	switch e := event.(type) {
+	case model.OrderCreated:
+		b, err := json.Marshal(OrderCreated{
+			Version:    EventVersion,
+			OrderID:    e.OrderID.String(),
+			CustomerID: e.CustomerID.String(),
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderItemChanged:
+		b, err := json.Marshal(OrderItemChanged{
+			Version:      EventVersion,
+			OrderID:      e.OrderID.String(),
+			AddedItems:   uuidsToStrings(e.AddedItems),
+			RemovedItems: uuidsToStrings(e.RemovedItems),
+			ChangedItems: uuidsToStrings(e.ChangedItems),
+			Total:        moneyToPayload(e.Total),
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderStatusChanged:
+		b, err := json.Marshal(OrderStatusChanged{
+			Version:   EventVersion,
+			OrderID:   e.OrderID.String(),
+			OldStatus: int(e.OldStatus),
+			NewStatus: int(e.NewStatus),
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderItemRemoved:
+		b, err := json.Marshal(OrderItemRemoved{
+			Version: EventVersion,
+			OrderID: e.OrderID.String(),
+			ItemID:  e.ItemID.String(),
+			Total:   moneyToPayload(e.Total),
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderRemoved:
+		b, err := json.Marshal(OrderRemoved{
+			Version: EventVersion,
+			OrderID: e.OrderID.String(),
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderCancelled:
+		b, err := json.Marshal(OrderCancelled{
+			Version:     EventVersion,
+			OrderID:     e.OrderID.String(),
+			Reason:      e.Reason,
+			CancelledBy: e.CancelledBy,
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderPaymentFailed:
+		b, err := json.Marshal(OrderPaymentFailed{
+			Version: EventVersion,
+			OrderID: e.OrderID.String(),
+			Reason:  e.Reason,
+		})
+		return string(b), errors.WithStack(err)
+	case model.OrderPromoCodeApplied:
+		b, err := json.Marshal(OrderPromoCodeApplied{
+			Version:  EventVersion,
+			OrderID:  e.OrderID.String(),
+			Code:     e.Code,
+			Discount: moneyToPayload(e.Discount),
+			Total:    moneyToPayload(e.Total),
+		})
+		return string(b), errors.WithStack(err)
+	default:
+		return "", errors.Errorf("unknown event %q", event.Type())
+	}
+}
+
+type OrderCreated struct {
+	Version    int    `json:"version"`
+	OrderID    string `json:"order_id"`
+	CustomerID string `json:"customer_id"`
+}
+
+type OrderItemChanged struct {
+	Version      int      `json:"version"`
+	OrderID      string   `json:"order_id"`
+	AddedItems   []string `json:"added_items,omitempty"`
+	RemovedItems []string `json:"removed_items,omitempty"`
+	ChangedItems []string `json:"changed_items,omitempty"`
+	Total        Money    `json:"total"`
+}
+
+type OrderStatusChanged struct {
+	Version   int    `json:"version"`
+	OrderID   string `json:"order_id"`
+	OldStatus int    `json:"old_status"`
+	NewStatus int    `json:"new_status"`
+}
+
+type OrderItemRemoved struct {
+	Version int    `json:"version"`
+	OrderID string `json:"order_id"`
+	ItemID  string `json:"item_id"`
+	Total   Money  `json:"total"`
+}
+
+type OrderRemoved struct {
+	Version int    `json:"version"`
+	OrderID string `json:"order_id"`
+}
+
+type OrderCancelled struct {
+	Version     int    `json:"version"`
+	OrderID     string `json:"order_id"`
+	Reason      string `json:"reason"`
+	CancelledBy string `json:"cancelled_by"`
+}
+
+type OrderPaymentFailed struct {
+	Version int    `json:"version"`
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+type OrderPromoCodeApplied struct {
+	Version  int    `json:"version"`
+	OrderID  string `json:"order_id"`
+	Code     string `json:"code"`
+	Discount Money  `json:"discount"`
+	Total    Money  `json:"total"`
+}
+
+// Money amount is kept in minor units of currency
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func moneyToPayload(money model.Money) Money {
+	return Money{
+		Amount:   money.Amount,
+		Currency: string(money.Currency),
+	}
+}
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"order/pkg/application/service"
+)
+
+// NewIdempotencyKeyStore works outside of unit of work, keys older than ttl are treated as absent
+// and calls in progress for longer than lease are treated as crashed
+func NewIdempotencyKeyStore(client mysql.ClientContext, ttl, lease time.Duration) service.IdempotencyKeyStore {
+	return &idempotencyKeyStore{
+		client: client,
+		ttl:    ttl,
+		lease:  lease,
+	}
+}
+
+type idempotencyKeyStore struct {
+	client mysql.ClientContext
+	ttl    time.Duration
+	lease  time.Duration
+}
+
+func (s *idempotencyKeyStore) Reserve(ctx context.Context, method, key, requestHash string) (*service.IdempotencyRecord, error) {
+	currentTime := time.Now()
+	_, err := s.client.ExecContext(ctx,
+		`DELETE FROM idempotency_key WHERE method = ? AND idempotency_key = ? AND created_at < ?`,
+		method,
+		key,
+		currentTime.Add(-s.ttl),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := s.client.ExecContext(ctx,
+		`INSERT IGNORE INTO idempotency_key (method, idempotency_key, request_hash, reserved_at, created_at) VALUES (?, ?, ?, ?, ?)`,
+		method,
+		key,
+		requestHash,
+		currentTime,
+		currentTime,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if affected > 0 {
+		return nil, nil
+	}
+
+	// call holding the reservation has not completed within the lease, so it is taken over by this one
+	res, err = s.client.ExecContext(ctx,
+		`
+	UPDATE idempotency_key SET request_hash = ?, reserved_at = ?, created_at = ?
+	WHERE method = ? AND idempotency_key = ? AND response IS NULL AND reserved_at < ?
+	`,
+		requestHash,
+		currentTime,
+		currentTime,
+		method,
+		key,
+		currentTime.Add(-s.lease),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	affected, err = res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if affected > 0 {
+		return nil, nil
+	}
+
+	var record sqlxIdempotencyRecord
+	err = s.client.GetContext(ctx,
+		&record,
+		`SELECT request_hash, response FROM idempotency_key WHERE method = ? AND idempotency_key = ?`,
+		method,
+		key,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &service.IdempotencyRecord{
+		RequestHash: record.RequestHash,
+		Response:    record.Response,
+	}, nil
+}
+
+func (s *idempotencyKeyStore) Complete(ctx context.Context, method, key string, response []byte) error {
+	_, err := s.client.ExecContext(ctx,
+		`UPDATE idempotency_key SET response = ? WHERE method = ? AND idempotency_key = ?`,
+		response,
+		method,
+		key,
+	)
+	return errors.WithStack(err)
+}
+
+func (s *idempotencyKeyStore) Release(ctx context.Context, method, key string) error {
+	_, err := s.client.ExecContext(ctx,
+		`DELETE FROM idempotency_key WHERE method = ? AND idempotency_key = ? AND response IS NULL`,
+		method,
+		key,
+	)
+	return errors.WithStack(err)
+}
+
+type sqlxIdempotencyRecord struct {
+	RequestHash string `db:"request_hash"`
+	// Response is nil while the first call is in progress
+	Response []byte `db:"response"`
+}
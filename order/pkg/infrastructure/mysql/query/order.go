@@ -0,0 +1,239 @@
+package query
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql/schema"
+)
+
+func NewOrderQueryService(client mysql.ClientContext) query.OrderQueryService {
+	return &orderQueryService{
+		client: client,
+	}
+}
+
+type orderQueryService struct {
+	client mysql.ClientContext
+}
+
+var sortColumns = map[query.OrderSortField]string{
+	query.SortByCreatedAt: "created_at",
+	query.SortByUpdatedAt: "updated_at",
+}
+
+func (o *orderQueryService) ListOrders(ctx context.Context, spec query.ListOrdersSpec) (query.OrderPage, error) {
+	sortColumn, ok := sortColumns[spec.SortBy]
+	if !ok {
+		return query.OrderPage{}, errors.Errorf("unknown sort field %d", spec.SortBy)
+	}
+	pageSize := spec.PageSize
+	if pageSize <= 0 {
+		pageSize = query.DefaultPageSize
+	}
+	pageSize = min(pageSize, query.MaxPageSize)
+
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	if spec.CustomerID != nil {
+		conditions = append(conditions, "customer_id = ?")
+		args = append(args, *spec.CustomerID)
+	}
+	if spec.ProductID != nil {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM order_item oi WHERE oi.order_id = orders.order_id AND oi.product_id = ?)")
+		args = append(args, *spec.ProductID)
+	}
+	if len(spec.Statuses) > 0 {
+		conditions = append(conditions, "status IN (?"+strings.Repeat(", ?", len(spec.Statuses)-1)+")")
+		for _, status := range spec.Statuses {
+			args = append(args, status)
+		}
+	}
+	if spec.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *spec.CreatedFrom)
+	}
+	if spec.CreatedTo != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *spec.CreatedTo)
+	}
+	if spec.UpdatedBefore != nil {
+		conditions = append(conditions, "updated_at < ?")
+		args = append(args, *spec.UpdatedBefore)
+	}
+
+	direction, comparison := "ASC", ">"
+	if spec.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if spec.Cursor != "" {
+		c, err := decodeCursor(spec.Cursor)
+		if err != nil {
+			return query.OrderPage{}, err
+		}
+		if c.SortBy != spec.SortBy || c.Descending != spec.Descending {
+			return query.OrderPage{}, errors.WithStack(query.ErrInvalidCursor)
+		}
+		// keyset pagination, order_id breaks ties of equal sort values
+		conditions = append(conditions, "("+sortColumn+" "+comparison+" ? OR ("+sortColumn+" = ? AND order_id "+comparison+" ?))")
+		args = append(args, c.Value, c.Value, c.OrderID)
+	}
+
+	var orders []schema.Order
+	err := o.client.SelectContext(
+		ctx,
+		&orders,
+		`SELECT `+schema.OrderColumns+`
+		FROM orders
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY `+sortColumn+` `+direction+`, order_id `+direction+`
+		LIMIT ?`,
+		append(args, pageSize+1)...,
+	)
+	if err != nil {
+		return query.OrderPage{}, errors.WithStack(err)
+	}
+
+	var nextCursor string
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		last := orders[len(orders)-1]
+		nextCursor, err = encodeCursor(cursor{
+			SortBy:     spec.SortBy,
+			Descending: spec.Descending,
+			Value:      sortValue(last, spec.SortBy),
+			OrderID:    last.OrderID,
+		})
+		if err != nil {
+			return query.OrderPage{}, err
+		}
+	}
+
+	items, err := o.findItems(ctx, orders)
+	if err != nil {
+		return query.OrderPage{}, err
+	}
+
+	result := make([]*model.Order, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, order.ToModel(items[order.OrderID]))
+	}
+	return query.OrderPage{
+		Orders:     result,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (o *orderQueryService) GetOrderHistory(ctx context.Context, orderID uuid.UUID) ([]query.OrderHistoryEntry, error) {
+	var entries []struct {
+		EventType  string    `db:"event_type"`
+		Payload    string    `db:"payload"`
+		Actor      string    `db:"actor"`
+		OccurredAt time.Time `db:"occurred_at"`
+	}
+	err := o.client.SelectContext(
+		ctx,
+		&entries,
+		`SELECT event_type, payload, actor, occurred_at FROM order_history WHERE order_id = ? ORDER BY id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(entries) == 0 {
+		// orders created before history was introduced have no entries
+		var exists bool
+		err = o.client.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM orders WHERE order_id = ?)`, orderID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !exists {
+			return nil, errors.WithStack(model.ErrOrderNotFound)
+		}
+	}
+
+	result := make([]query.OrderHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, query.OrderHistoryEntry{
+			EventType:  entry.EventType,
+			Payload:    entry.Payload,
+			Actor:      entry.Actor,
+			OccurredAt: entry.OccurredAt,
+		})
+	}
+	return result, nil
+}
+
+func (o *orderQueryService) findItems(ctx context.Context, orders []schema.Order) (map[uuid.UUID][]schema.Item, error) {
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, 0, len(orders))
+	for _, order := range orders {
+		args = append(args, order.OrderID)
+	}
+
+	var items []schema.Item
+	err := o.client.SelectContext(
+		ctx,
+		&items,
+		`SELECT `+schema.ItemColumns+` FROM order_item
+		WHERE order_id IN (?`+strings.Repeat(", ?", len(args)-1)+`)
+		ORDER BY item_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	itemsByOrder := make(map[uuid.UUID][]schema.Item, len(orders))
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+	return itemsByOrder, nil
+}
+
+// cursor points to the last order of the page
+type cursor struct {
+	SortBy     query.OrderSortField `json:"s"`
+	Descending bool                 `json:"d"`
+	Value      time.Time            `json:"v"`
+	OrderID    uuid.UUID            `json:"id"`
+}
+
+func encodeCursor(c cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, errors.WithStack(query.ErrInvalidCursor)
+	}
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return cursor{}, errors.WithStack(query.ErrInvalidCursor)
+	}
+	return c, nil
+}
+
+func sortValue(order schema.Order, sortBy query.OrderSortField) time.Time {
+	if sortBy == query.SortByUpdatedAt {
+		return order.UpdatedAt
+	}
+	return order.CreatedAt
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql/schema"
+)
+
+func NewOrderRepository(ctx context.Context, client mysql.ClientContext) model.OrderRepository {
+	return &orderRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type orderRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (o *orderRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (o *orderRepository) Store(order *model.Order) error {
+	err := o.storeOrder(order)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.client.ExecContext(o.ctx, `DELETE FROM order_item WHERE order_id = ?`, order.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, item := range order.Items {
+		_, err = o.client.ExecContext(o.ctx,
+			`INSERT INTO order_item (item_id, order_id, product_id, variant_id, product_name, quantity, price_amount, currency) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			item.ID,
+			order.ID,
+			item.ProductID,
+			item.VariantID,
+			item.ProductName,
+			item.Quantity,
+			item.Price.Amount,
+			item.Price.Currency,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	order.Version++
+	return nil
+}
+
+// storeOrder inserts never stored order or updates order only if it has not been changed since it was read
+func (o *orderRepository) storeOrder(order *model.Order) error {
+	cancellation := schema.ToSQLCancellation(order.Cancellation)
+	promoCode := schema.ToSQLAppliedPromoCode(order.PromoCode)
+	if order.Version == 0 {
+		_, err := o.client.ExecContext(o.ctx,
+			`
+		INSERT INTO orders (order_id, customer_id, status, created_at, updated_at, deleted_at, cancellation_reason, cancelled_by, cancelled_at, payment_failure_reason,
+			promo_code, discount_type, discount_percent, discount_amount, discount_currency, discount_product_id, discount_buy_quantity, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)
+		`,
+			order.ID,
+			order.CustomerID,
+			order.Status,
+			order.CreatedAt,
+			order.UpdatedAt,
+			schema.ToSQLNull(order.DeletedAt),
+			cancellation.Reason,
+			cancellation.CancelledBy,
+			cancellation.CancelledAt,
+			order.PaymentFailureReason,
+			promoCode.Code,
+			promoCode.DiscountType,
+			promoCode.DiscountPercent,
+			promoCode.DiscountAmount,
+			promoCode.DiscountCurrency,
+			promoCode.DiscountProductID,
+			promoCode.DiscountBuyQuantity,
+		)
+		return errors.WithStack(err)
+	}
+
+	res, err := o.client.ExecContext(o.ctx,
+		`
+	UPDATE orders SET
+		customer_id = ?,
+		status = ?,
+		updated_at = ?,
+		deleted_at = ?,
+		cancellation_reason = ?,
+		cancelled_by = ?,
+		cancelled_at = ?,
+		payment_failure_reason = ?,
+		promo_code = ?,
+		discount_type = ?,
+		discount_percent = ?,
+		discount_amount = ?,
+		discount_currency = ?,
+		discount_product_id = ?,
+		discount_buy_quantity = ?,
+		version = version + 1
+	WHERE order_id = ? AND version = ?
+	`,
+		order.CustomerID,
+		order.Status,
+		order.UpdatedAt,
+		schema.ToSQLNull(order.DeletedAt),
+		cancellation.Reason,
+		cancellation.CancelledBy,
+		cancellation.CancelledAt,
+		order.PaymentFailureReason,
+		promoCode.Code,
+		promoCode.DiscountType,
+		promoCode.DiscountPercent,
+		promoCode.DiscountAmount,
+		promoCode.DiscountCurrency,
+		promoCode.DiscountProductID,
+		promoCode.DiscountBuyQuantity,
+		order.ID,
+		order.Version,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrOrderVersionConflict)
+	}
+	return nil
+}
+
+func (o *orderRepository) Find(id uuid.UUID) (*model.Order, error) {
+	var order schema.Order
+	err := o.client.GetContext(
+		o.ctx,
+		&order,
+		`SELECT `+schema.OrderColumns+` FROM orders WHERE order_id = ? AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrOrderNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	var items []schema.Item
+	err = o.client.SelectContext(
+		o.ctx,
+		&items,
+		`SELECT `+schema.ItemColumns+` FROM order_item WHERE order_id = ? ORDER BY item_id`,
+		id,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return order.ToModel(items), nil
+}
+
+func (o *orderRepository) Delete(order *model.Order) error {
+	currentTime := time.Now()
+	res, err := o.client.ExecContext(o.ctx,
+		`UPDATE orders SET deleted_at = ?, updated_at = ?, version = version + 1 WHERE order_id = ? AND version = ? AND deleted_at IS NULL`,
+		currentTime,
+		currentTime,
+		order.ID,
+		order.Version,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrOrderVersionConflict)
+	}
+	order.DeletedAt = &currentTime
+	order.UpdatedAt = currentTime
+	order.Version++
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/integrationevent"
+)
+
+func NewOrderHistoryRepository(ctx context.Context, client mysql.ClientContext) model.OrderHistoryRepository {
+	return &orderHistoryRepository{
+		ctx:        ctx,
+		client:     client,
+		serializer: integrationevent.NewEventSerializer(),
+	}
+}
+
+type orderHistoryRepository struct {
+	ctx        context.Context
+	client     mysql.ClientContext
+	serializer outbox.EventSerializer[outbox.Event]
+}
+
+func (o *orderHistoryRepository) Append(event model.OrderEvent, actor string, occurredAt time.Time) error {
+	// payload has the same shape as published integration event
+	payload, err := o.serializer.Serialize(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.client.ExecContext(o.ctx,
+		`INSERT INTO order_history (order_id, event_type, payload, actor, occurred_at) VALUES (?, ?, ?, ?, ?)`,
+		event.AggregateID(),
+		event.Type(),
+		payload,
+		actor,
+		occurredAt,
+	)
+	return errors.WithStack(err)
+}
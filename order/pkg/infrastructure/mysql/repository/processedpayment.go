@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"order/pkg/application/service"
+)
+
+func NewProcessedPaymentRepository(ctx context.Context, client mysql.ClientContext) service.ProcessedPaymentRepository {
+	return &processedPaymentRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type processedPaymentRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *processedPaymentRepository) MarkProcessed(transactionID, orderID uuid.UUID) (bool, error) {
+	res, err := p.client.ExecContext(p.ctx,
+		`INSERT IGNORE INTO processed_payment_transaction (transaction_id, order_id, processed_at) VALUES (?, ?, ?)`,
+		transactionID,
+		orderID,
+		time.Now(),
+	)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return affected > 0, nil
+}
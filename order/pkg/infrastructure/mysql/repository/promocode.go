@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql/schema"
+)
+
+func NewPromoCodeRepository(ctx context.Context, client mysql.ClientContext) model.PromoCodeRepository {
+	return &promoCodeRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type promoCodeRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *promoCodeRepository) Find(code string) (*model.PromoCode, error) {
+	var promoCode sqlxPromoCode
+	err := p.client.GetContext(
+		p.ctx,
+		&promoCode,
+		`
+	SELECT code, discount_type, discount_percent, discount_amount, discount_currency, discount_product_id, discount_buy_quantity,
+		valid_from, valid_to, usage_limit
+	FROM promo_code WHERE code = ? FOR UPDATE
+	`,
+		code,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrPromoCodeNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.PromoCode{
+		Code: promoCode.Code,
+		Rule: model.DiscountRule{
+			Type:    model.DiscountType(promoCode.DiscountType),
+			Percent: promoCode.DiscountPercent,
+			Amount: model.Money{
+				Amount:   promoCode.DiscountAmount,
+				Currency: model.Currency(promoCode.DiscountCurrency),
+			},
+			ProductID:   promoCode.DiscountProductID.V,
+			BuyQuantity: promoCode.DiscountBuyQuantity,
+		},
+		ValidFrom:  promoCode.ValidFrom,
+		ValidTo:    schema.FromSQLNull(promoCode.ValidTo),
+		UsageLimit: promoCode.UsageLimit,
+	}, nil
+}
+
+// CountUsages uses locking read, since consistent read of the transaction snapshot misses orders
+// of the concurrent transaction that held promo code lock before
+func (p *promoCodeRepository) CountUsages(code string, customerID uuid.UUID, excludedOrderID uuid.UUID) (int, error) {
+	var count int
+	err := p.client.GetContext(
+		p.ctx,
+		&count,
+		`
+	SELECT COUNT(*) FROM orders
+	WHERE customer_id = ? AND promo_code = ? AND order_id <> ? AND status <> ? AND deleted_at IS NULL
+	FOR SHARE
+	`,
+		customerID,
+		code,
+		excludedOrderID,
+		model.Cancelled,
+	)
+	return count, errors.WithStack(err)
+}
+
+type sqlxPromoCode struct {
+	Code                string              `db:"code"`
+	DiscountType        int                 `db:"discount_type"`
+	DiscountPercent     int                 `db:"discount_percent"`
+	DiscountAmount      int64               `db:"discount_amount"`
+	DiscountCurrency    string              `db:"discount_currency"`
+	DiscountProductID   sql.Null[uuid.UUID] `db:"discount_product_id"`
+	DiscountBuyQuantity int                 `db:"discount_buy_quantity"`
+	ValidFrom           time.Time           `db:"valid_from"`
+	ValidTo             sql.Null[time.Time] `db:"valid_to"`
+	UsageLimit          int                 `db:"usage_limit"`
+}
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql/repository"
+)
+
+func NewRepositoryProvider(client mysql.ClientContext) service.RepositoryProvider {
+	return &repositoryProvider{client: client}
+}
+
+type repositoryProvider struct {
+	client mysql.ClientContext
+}
+
+func (r *repositoryProvider) OrderRepository(ctx context.Context) model.OrderRepository {
+	return repository.NewOrderRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) OrderHistoryRepository(ctx context.Context) model.OrderHistoryRepository {
+	return repository.NewOrderHistoryRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) PromoCodeRepository(ctx context.Context) model.PromoCodeRepository {
+	return repository.NewPromoCodeRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ProcessedPaymentRepository(ctx context.Context) service.ProcessedPaymentRepository {
+	return repository.NewProcessedPaymentRepository(ctx, r.client)
+}
@@ -0,0 +1,163 @@
+package schema
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+// OrderColumns are selected into Order by repositories and query services alike
+const OrderColumns = `order_id, customer_id, status, created_at, updated_at, deleted_at, cancellation_reason, cancelled_by, cancelled_at, payment_failure_reason,
+	promo_code, discount_type, discount_percent, discount_amount, discount_currency, discount_product_id, discount_buy_quantity, version`
+
+// ItemColumns are selected into Item
+const ItemColumns = `item_id, order_id, product_id, variant_id, product_name, quantity, price_amount, currency`
+
+type Order struct {
+	OrderID    uuid.UUID           `db:"order_id"`
+	CustomerID uuid.UUID           `db:"customer_id"`
+	Status     int                 `db:"status"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+	DeletedAt  sql.Null[time.Time] `db:"deleted_at"`
+	Cancellation
+	PaymentFailureReason string `db:"payment_failure_reason"`
+	AppliedPromoCode
+	Version int `db:"version"`
+}
+
+type Cancellation struct {
+	Reason      sql.Null[string]    `db:"cancellation_reason"`
+	CancelledBy sql.Null[string]    `db:"cancelled_by"`
+	CancelledAt sql.Null[time.Time] `db:"cancelled_at"`
+}
+
+func ToSQLCancellation(cancellation *model.Cancellation) Cancellation {
+	if cancellation == nil {
+		return Cancellation{}
+	}
+	return Cancellation{
+		Reason:      sql.Null[string]{V: cancellation.Reason, Valid: true},
+		CancelledBy: sql.Null[string]{V: cancellation.CancelledBy, Valid: true},
+		CancelledAt: sql.Null[time.Time]{V: cancellation.CancelledAt, Valid: true},
+	}
+}
+
+func (c Cancellation) ToModel() *model.Cancellation {
+	if !c.CancelledAt.Valid {
+		return nil
+	}
+	return &model.Cancellation{
+		Reason:      c.Reason.V,
+		CancelledBy: c.CancelledBy.V,
+		CancelledAt: c.CancelledAt.V,
+	}
+}
+
+type AppliedPromoCode struct {
+	Code                sql.Null[string]    `db:"promo_code"`
+	DiscountType        sql.Null[int]       `db:"discount_type"`
+	DiscountPercent     sql.Null[int]       `db:"discount_percent"`
+	DiscountAmount      sql.Null[int64]     `db:"discount_amount"`
+	DiscountCurrency    sql.Null[string]    `db:"discount_currency"`
+	DiscountProductID   sql.Null[uuid.UUID] `db:"discount_product_id"`
+	DiscountBuyQuantity sql.Null[int]       `db:"discount_buy_quantity"`
+}
+
+func ToSQLAppliedPromoCode(promoCode *model.AppliedPromoCode) AppliedPromoCode {
+	if promoCode == nil {
+		return AppliedPromoCode{}
+	}
+	rule := promoCode.Rule
+	result := AppliedPromoCode{
+		Code:                sql.Null[string]{V: promoCode.Code, Valid: true},
+		DiscountType:        sql.Null[int]{V: int(rule.Type), Valid: true},
+		DiscountPercent:     sql.Null[int]{V: rule.Percent, Valid: true},
+		DiscountAmount:      sql.Null[int64]{V: rule.Amount.Amount, Valid: true},
+		DiscountCurrency:    sql.Null[string]{V: string(rule.Amount.Currency), Valid: true},
+		DiscountBuyQuantity: sql.Null[int]{V: rule.BuyQuantity, Valid: true},
+	}
+	if rule.ProductID != uuid.Nil {
+		result.DiscountProductID = sql.Null[uuid.UUID]{V: rule.ProductID, Valid: true}
+	}
+	return result
+}
+
+func (p AppliedPromoCode) ToModel() *model.AppliedPromoCode {
+	if !p.Code.Valid {
+		return nil
+	}
+	return &model.AppliedPromoCode{
+		Code: p.Code.V,
+		Rule: model.DiscountRule{
+			Type:    model.DiscountType(p.DiscountType.V),
+			Percent: p.DiscountPercent.V,
+			Amount: model.Money{
+				Amount:   p.DiscountAmount.V,
+				Currency: model.Currency(p.DiscountCurrency.V),
+			},
+			ProductID:   p.DiscountProductID.V,
+			BuyQuantity: p.DiscountBuyQuantity.V,
+		},
+	}
+}
+
+type Item struct {
+	ItemID      uuid.UUID `db:"item_id"`
+	OrderID     uuid.UUID `db:"order_id"`
+	ProductID   uuid.UUID `db:"product_id"`
+	VariantID   uuid.UUID `db:"variant_id"`
+	ProductName string    `db:"product_name"`
+	Quantity    int       `db:"quantity"`
+	PriceAmount int64     `db:"price_amount"`
+	Currency    string    `db:"currency"`
+}
+
+func (o Order) ToModel(items []Item) *model.Order {
+	order := &model.Order{
+		ID:                   o.OrderID,
+		CustomerID:           o.CustomerID,
+		Status:               model.OrderStatus(o.Status),
+		CreatedAt:            o.CreatedAt,
+		UpdatedAt:            o.UpdatedAt,
+		DeletedAt:            FromSQLNull(o.DeletedAt),
+		Cancellation:         o.Cancellation.ToModel(),
+		PaymentFailureReason: o.PaymentFailureReason,
+		PromoCode:            o.AppliedPromoCode.ToModel(),
+		Version:              o.Version,
+	}
+	for _, item := range items {
+		order.Items = append(order.Items, model.Item{
+			ID:          item.ItemID,
+			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price: model.Money{
+				Amount:   item.PriceAmount,
+				Currency: model.Currency(item.Currency),
+			},
+		})
+	}
+	return order
+}
+
+func FromSQLNull[T any](v sql.Null[T]) *T {
+	if v.Valid {
+		return &v.V
+	}
+	return nil
+}
+
+func ToSQLNull[T any](v *T) sql.Null[T] {
+	if v == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{
+		V:     *v,
+		Valid: true,
+	}
+}
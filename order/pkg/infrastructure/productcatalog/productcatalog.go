@@ -0,0 +1,89 @@
+package productcatalog
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productapi "order/api/client/productinternal"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewProductCatalogProvider(client productapi.ProductInternalServiceClient) service.ProductCatalogProvider {
+	return &productCatalogProvider{client: client}
+}
+
+type productCatalogProvider struct {
+	client productapi.ProductInternalServiceClient
+}
+
+func (p *productCatalogProvider) ProductCatalog(ctx context.Context) model.ProductCatalog {
+	return &productCatalog{
+		ctx:    ctx,
+		client: p.client,
+	}
+}
+
+type productCatalog struct {
+	ctx    context.Context
+	client productapi.ProductInternalServiceClient
+}
+
+func (p *productCatalog) FindProduct(id uuid.UUID, variantID uuid.UUID) (model.Product, error) {
+	if variantID != uuid.Nil {
+		return p.findVariant(id, variantID)
+	}
+
+	response, err := p.client.GetProduct(p.ctx, &productapi.GetProductRequest{
+		ProductID: id.String(),
+	})
+	if err != nil {
+		return model.Product{}, mapError(err)
+	}
+
+	return model.Product{
+		ID:    id,
+		Name:  response.Product.Name,
+		Price: priceToMoney(response.Product.Price),
+	}, nil
+}
+
+func (p *productCatalog) findVariant(id uuid.UUID, variantID uuid.UUID) (model.Product, error) {
+	response, err := p.client.GetVariant(p.ctx, &productapi.GetVariantRequest{
+		VariantID: variantID.String(),
+	})
+	if err != nil {
+		return model.Product{}, mapError(err)
+	}
+	// variant of another product is not a variant of requested one
+	if response.Variant.ProductID != id.String() {
+		return model.Product{}, errors.WithStack(model.ErrProductNotFound)
+	}
+
+	return model.Product{
+		ID:        id,
+		VariantID: variantID,
+		Name:      response.Variant.Title,
+		Price:     priceToMoney(response.Variant.Price),
+	}, nil
+}
+
+func mapError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return errors.WithStack(model.ErrProductNotFound)
+	}
+	return errors.WithStack(err)
+}
+
+func priceToMoney(price float64) model.Money {
+	return model.Money{
+		// product service still keeps price as float
+		Amount:   int64(math.Round(price * 100)),
+		Currency: model.DefaultCurrency,
+	}
+}
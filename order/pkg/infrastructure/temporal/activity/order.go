@@ -0,0 +1,83 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+
+	"order/pkg/application/query"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewOrderServiceActivities(
+	orderService service.OrderService,
+	orderQueryService query.OrderQueryService,
+) *OrderServiceActivities {
+	return &OrderServiceActivities{
+		orderService:      orderService,
+		orderQueryService: orderQueryService,
+	}
+}
+
+type OrderServiceActivities struct {
+	orderService      service.OrderService
+	orderQueryService query.OrderQueryService
+}
+
+func (a *OrderServiceActivities) GetOrder(ctx context.Context, orderID uuid.UUID) (model.Order, error) {
+	order, err := a.orderService.GetOrder(ctx, orderID)
+	if err != nil {
+		return model.Order{}, orderError(err)
+	}
+	return *order, nil
+}
+
+func (a *OrderServiceActivities) SetOrderStatus(ctx context.Context, orderID uuid.UUID, status int) error {
+	return orderError(a.orderService.SetStatus(systemContext(ctx), orderID, model.OrderStatus(status)))
+}
+
+// CancelOrder cancels order on behalf of the service
+func (a *OrderServiceActivities) CancelOrder(ctx context.Context, orderID uuid.UUID, reason string) error {
+	return orderError(a.orderService.CancelOrder(systemContext(ctx), orderID, reason))
+}
+
+// FindExpiredPendingOrders returns a batch of orders staying Pending since before pendingBefore
+func (a *OrderServiceActivities) FindExpiredPendingOrders(ctx context.Context, pendingBefore time.Time) ([]uuid.UUID, error) {
+	page, err := a.orderQueryService.ListOrders(ctx, query.ListOrdersSpec{
+		Statuses:      []model.OrderStatus{model.Pending},
+		UpdatedBefore: &pendingBefore,
+		SortBy:        query.SortByUpdatedAt,
+		PageSize:      query.MaxPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]uuid.UUID, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+	return orderIDs, nil
+}
+
+func (a *OrderServiceActivities) ExpirePendingOrder(ctx context.Context, orderID uuid.UUID, pendingBefore time.Time) error {
+	return orderError(a.orderService.ExpirePendingOrder(systemContext(ctx), orderID, pendingBefore))
+}
+
+// systemContext attributes changes made by workflows to the service itself
+func systemContext(ctx context.Context) context.Context {
+	return service.WithActor(ctx, model.SystemActor)
+}
+
+// orderError stops retries for errors that will not go away on their own
+func orderError(err error) error {
+	if errors.Is(err, model.ErrOrderNotFound) || errors.Is(err, model.ErrInvalidStatusTransition) ||
+		errors.Is(err, model.ErrInvalidCancellation) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "OrderError", err)
+	}
+	return err
+}
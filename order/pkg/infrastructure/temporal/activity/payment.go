@@ -0,0 +1,73 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentapi "order/api/client/paymentinternal"
+	"order/pkg/domain/model"
+)
+
+func NewPaymentServiceActivities(client paymentapi.PaymentInternalServiceClient) *PaymentServiceActivities {
+	return &PaymentServiceActivities{client: client}
+}
+
+type PaymentServiceActivities struct {
+	client paymentapi.PaymentInternalServiceClient
+}
+
+type ChargeResult struct {
+	TransactionID string
+	// Declined is set when payment service refused the charge because of insufficient funds,
+	// the refusal is also published as PaymentFailed event
+	Declined bool
+	Reason   string
+}
+
+// ChargeOrder charges the order once per workflow run, so activity retries are the same payment attempt
+func (a *PaymentServiceActivities) ChargeOrder(ctx context.Context, customerID, orderID uuid.UUID, amount model.Money) (ChargeResult, error) {
+	response, err := a.client.ProcessPayment(ctx, &paymentapi.ProcessPaymentRequest{
+		UserID:    customerID.String(),
+		OrderID:   orderID.String(),
+		Amount:    fromMinorUnits(amount.Amount),
+		AttemptID: activity.GetInfo(ctx).WorkflowExecution.RunID,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return ChargeResult{
+				Declined: true,
+				Reason:   status.Convert(err).Message(),
+			}, nil
+		case codes.NotFound, codes.InvalidArgument:
+			// e.g. customer has no account, retries will not help
+			return ChargeResult{}, temporal.NewNonRetryableApplicationError(status.Convert(err).Message(), "PaymentError", err)
+		default:
+			return ChargeResult{}, err
+		}
+	}
+	return ChargeResult{
+		TransactionID: response.Transaction.TransactionID,
+	}, nil
+}
+
+func (a *PaymentServiceActivities) RefundOrder(ctx context.Context, orderID uuid.UUID) error {
+	_, err := a.client.RefundPayment(ctx, &paymentapi.RefundPaymentRequest{
+		OrderID: orderID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		// nothing was charged for the order
+		return nil
+	}
+	return err
+}
+
+// fromMinorUnits converts amount to float price still used by payment service
+func fromMinorUnits(amount int64) float64 {
+	return float64(amount) / 100
+}
@@ -0,0 +1,105 @@
+package activity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productapi "order/api/client/productinternal"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewProductServiceActivities(
+	productCatalogProvider service.ProductCatalogProvider,
+	client productapi.ProductInternalServiceClient,
+) *ProductServiceActivities {
+	return &ProductServiceActivities{
+		productCatalogProvider: productCatalogProvider,
+		client:                 client,
+	}
+}
+
+type ProductServiceActivities struct {
+	productCatalogProvider service.ProductCatalogProvider
+	client                 productapi.ProductInternalServiceClient
+}
+
+type ReservationResult struct {
+	// Rejected is set when product service refused to reserve items, e.g. because of insufficient stock
+	Rejected bool
+	Reason   string
+}
+
+// FindMismatchedItems returns items whose product or variant is gone from the catalog or whose price differs from the catalog one
+func (a *ProductServiceActivities) FindMismatchedItems(ctx context.Context, items []model.Item) ([]uuid.UUID, error) {
+	catalog := a.productCatalogProvider.ProductCatalog(ctx)
+
+	var mismatched []uuid.UUID
+	for _, item := range items {
+		product, err := catalog.FindProduct(item.ProductID, item.VariantID)
+		if err != nil {
+			if errors.Is(err, model.ErrProductNotFound) {
+				mismatched = append(mismatched, item.ID)
+				continue
+			}
+			return nil, err
+		}
+		if product.Price != item.Price {
+			mismatched = append(mismatched, item.ID)
+		}
+	}
+	return mismatched, nil
+}
+
+// ReserveStock holds units of order items until the order is paid, either all items are reserved or none
+func (a *ProductServiceActivities) ReserveStock(ctx context.Context, orderID uuid.UUID, items []model.Item) (ReservationResult, error) {
+	reservationItems := make([]*productapi.ReservationItem, 0, len(items))
+	for _, item := range items {
+		var variantID string
+		if item.VariantID != uuid.Nil {
+			variantID = item.VariantID.String()
+		}
+		reservationItems = append(reservationItems, &productapi.ReservationItem{
+			ProductID: item.ProductID.String(),
+			VariantID: variantID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	_, err := a.client.ReserveStock(ctx, &productapi.ReserveStockRequest{
+		OrderID: orderID.String(),
+		Items:   reservationItems,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.NotFound, codes.InvalidArgument:
+			return ReservationResult{
+				Rejected: true,
+				Reason:   status.Convert(err).Message(),
+			}, nil
+		default:
+			return ReservationResult{}, err
+		}
+	}
+	return ReservationResult{}, nil
+}
+
+// ReleaseStock returns reserved units of the order to stock
+func (a *ProductServiceActivities) ReleaseStock(ctx context.Context, orderID uuid.UUID) error {
+	_, err := a.client.ReleaseStock(ctx, &productapi.ReleaseStockRequest{
+		OrderID: orderID.String(),
+	})
+	return err
+}
+
+// CommitStock makes reserved units of the paid order sold
+func (a *ProductServiceActivities) CommitStock(ctx context.Context, orderID uuid.UUID) error {
+	_, err := a.client.CommitStock(ctx, &productapi.CommitStockRequest{
+		OrderID: orderID.String(),
+	})
+	return err
+}
@@ -0,0 +1,59 @@
+package temporal
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+
+	"order/pkg/infrastructure/temporal/workflows"
+)
+
+const TaskQueue = "order_task_queue"
+
+// expirePendingOrdersSchedule runs expiration every minute
+const expirePendingOrdersSchedule = "* * * * *"
+
+type WorkflowService interface {
+	RunCheckoutWorkflow(ctx context.Context, orderID uuid.UUID) error
+	// StartPendingOrdersExpiration schedules cancellation of orders staying Pending longer than pendingTTL,
+	// already scheduled expiration is left as is
+	StartPendingOrdersExpiration(ctx context.Context, pendingTTL time.Duration) error
+}
+
+func NewWorkflowService(temporalClient client.Client) WorkflowService {
+	return &workflowService{
+		temporalClient: temporalClient,
+	}
+}
+
+type workflowService struct {
+	temporalClient client.Client
+}
+
+func (s *workflowService) RunCheckoutWorkflow(ctx context.Context, orderID uuid.UUID) error {
+	_, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			// one checkout per order at a time, repeated requests attach to the running workflow
+			ID:        "checkout_" + orderID.String(),
+			TaskQueue: TaskQueue,
+		},
+		workflows.CheckoutWorkflow, orderID,
+	)
+	return err
+}
+
+func (s *workflowService) StartPendingOrdersExpiration(ctx context.Context, pendingTTL time.Duration) error {
+	_, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:           "expire_pending_orders",
+			TaskQueue:    TaskQueue,
+			CronSchedule: expirePendingOrdersSchedule,
+		},
+		workflows.ExpirePendingOrdersWorkflow, pendingTTL,
+	)
+	return err
+}
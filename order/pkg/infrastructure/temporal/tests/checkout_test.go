@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/temporal/activity"
+	"order/pkg/infrastructure/temporal/workflows"
+)
+
+func TestCheckoutWorkflow(t *testing.T) {
+	orderID := uuid.Must(uuid.NewV7())
+	order := model.Order{
+		ID:         orderID,
+		CustomerID: uuid.Must(uuid.NewV7()),
+		Status:     model.Pending,
+		Items: []model.Item{{
+			ID:        uuid.Must(uuid.NewV7()),
+			ProductID: uuid.Must(uuid.NewV7()),
+			Quantity:  1,
+			Price:     model.Money{Amount: 1000, Currency: model.DefaultCurrency},
+		}},
+	}
+
+	t.Run("MarksOrderPaid", func(t *testing.T) {
+		env := newCheckoutEnvironment(t)
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Pending)).Return(nil).Once()
+		env.OnActivity("GetOrder", mock.Anything, orderID).Return(order, nil).Once()
+		env.OnActivity("FindMismatchedItems", mock.Anything, mock.Anything).Return([]uuid.UUID(nil), nil).Once()
+		env.OnActivity("ReserveStock", mock.Anything, orderID, mock.Anything).Return(activity.ReservationResult{}, nil).Once()
+		env.OnActivity("ChargeOrder", mock.Anything, order.CustomerID, orderID, order.Total()).
+			Return(activity.ChargeResult{TransactionID: uuid.NewString()}, nil).Once()
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Paid)).Return(nil).Once()
+		env.OnActivity("CommitStock", mock.Anything, orderID).Return(nil).Once()
+
+		env.ExecuteWorkflow(workflows.CheckoutWorkflow, orderID)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+	})
+
+	t.Run("LeavesDeclinedOrderToPaymentFailedConsumer", func(t *testing.T) {
+		env := newCheckoutEnvironment(t)
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Pending)).Return(nil).Once()
+		env.OnActivity("GetOrder", mock.Anything, orderID).Return(order, nil).Once()
+		env.OnActivity("FindMismatchedItems", mock.Anything, mock.Anything).Return([]uuid.UUID(nil), nil).Once()
+		env.OnActivity("ReserveStock", mock.Anything, orderID, mock.Anything).Return(activity.ReservationResult{}, nil).Once()
+		env.OnActivity("ChargeOrder", mock.Anything, order.CustomerID, orderID, order.Total()).
+			Return(activity.ChargeResult{Declined: true, Reason: "insufficient funds on account"}, nil).Once()
+		env.OnActivity("ReleaseStock", mock.Anything, orderID).Return(nil).Once()
+
+		env.ExecuteWorkflow(workflows.CheckoutWorkflow, orderID)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "RefundOrder", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "SetOrderStatus", mock.Anything, orderID, int(model.Paid))
+		env.AssertNotCalled(t, "CommitStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CancelsOrderWithRejectedReservation", func(t *testing.T) {
+		env := newCheckoutEnvironment(t)
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Pending)).Return(nil).Once()
+		env.OnActivity("GetOrder", mock.Anything, orderID).Return(order, nil).Once()
+		env.OnActivity("FindMismatchedItems", mock.Anything, mock.Anything).Return([]uuid.UUID(nil), nil).Once()
+		env.OnActivity("ReserveStock", mock.Anything, orderID, mock.Anything).
+			Return(activity.ReservationResult{Rejected: true, Reason: "insufficient stock"}, nil).Once()
+		env.OnActivity("CancelOrder", mock.Anything, orderID, "order items are out of stock").Return(nil).Once()
+
+		env.ExecuteWorkflow(workflows.CheckoutWorkflow, orderID)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, "ChargeOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReleasesStockAndRefundsWhenOrderCannotBePaid", func(t *testing.T) {
+		env := newCheckoutEnvironment(t)
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Pending)).Return(nil).Once()
+		env.OnActivity("GetOrder", mock.Anything, orderID).Return(order, nil).Once()
+		env.OnActivity("FindMismatchedItems", mock.Anything, mock.Anything).Return([]uuid.UUID(nil), nil).Once()
+		env.OnActivity("ReserveStock", mock.Anything, orderID, mock.Anything).Return(activity.ReservationResult{}, nil).Once()
+		env.OnActivity("ChargeOrder", mock.Anything, order.CustomerID, orderID, order.Total()).
+			Return(activity.ChargeResult{TransactionID: uuid.NewString()}, nil).Once()
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Paid)).
+			Return(temporal.NewNonRetryableApplicationError("order version conflict", "conflict", nil)).Once()
+		env.OnActivity("RefundOrder", mock.Anything, orderID).Return(nil).Once()
+		env.OnActivity("ReleaseStock", mock.Anything, orderID).Return(nil).Once()
+		env.OnActivity("CancelOrder", mock.Anything, orderID, "checkout failed").Return(nil).Once()
+
+		env.ExecuteWorkflow(workflows.CheckoutWorkflow, orderID)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, "CommitStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CancelsOrderWithMismatchedItems", func(t *testing.T) {
+		env := newCheckoutEnvironment(t)
+		env.OnActivity("SetOrderStatus", mock.Anything, orderID, int(model.Pending)).Return(nil).Once()
+		env.OnActivity("GetOrder", mock.Anything, orderID).Return(order, nil).Once()
+		env.OnActivity("FindMismatchedItems", mock.Anything, mock.Anything).Return([]uuid.UUID{order.Items[0].ID}, nil).Once()
+		env.OnActivity("CancelOrder", mock.Anything, orderID, "order items do not match product catalog").Return(nil).Once()
+
+		env.ExecuteWorkflow(workflows.CheckoutWorkflow, orderID)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, "ChargeOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func newCheckoutEnvironment(t *testing.T) *testsuite.TestWorkflowEnvironment {
+	t.Helper()
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(activity.NewOrderServiceActivities(nil, nil))
+	env.RegisterActivity(activity.NewProductServiceActivities(nil, nil))
+	env.RegisterActivity(activity.NewPaymentServiceActivities(nil))
+	return env
+}
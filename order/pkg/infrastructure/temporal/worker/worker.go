@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+
+	paymentapi "order/api/client/paymentinternal"
+	productapi "order/api/client/productinternal"
+	"order/pkg/application/query"
+	"order/pkg/application/service"
+	"order/pkg/infrastructure/temporal"
+	"order/pkg/infrastructure/temporal/activity"
+	"order/pkg/infrastructure/temporal/workflows"
+)
+
+func InterruptChannel() <-chan interface{} {
+	return worker.InterruptCh()
+}
+
+func NewWorker(
+	temporalClient client.Client,
+	orderService service.OrderService,
+	orderQueryService query.OrderQueryService,
+	productCatalogProvider service.ProductCatalogProvider,
+	productClient productapi.ProductInternalServiceClient,
+	paymentClient paymentapi.PaymentInternalServiceClient,
+) worker.Worker {
+	w := worker.New(temporalClient, temporal.TaskQueue, worker.Options{})
+	w.RegisterActivity(activity.NewOrderServiceActivities(orderService, orderQueryService))
+	w.RegisterActivity(activity.NewProductServiceActivities(productCatalogProvider, productClient))
+	w.RegisterActivity(activity.NewPaymentServiceActivities(paymentClient))
+	w.RegisterWorkflow(workflows.CheckoutWorkflow)
+	w.RegisterWorkflow(workflows.ExpirePendingOrdersWorkflow)
+	return w
+}
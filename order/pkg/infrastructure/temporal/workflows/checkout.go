@@ -0,0 +1,114 @@
+package workflows
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/temporal/activity"
+)
+
+var (
+	orderServiceActivities   *activity.OrderServiceActivities
+	productServiceActivities *activity.ProductServiceActivities
+	paymentServiceActivities *activity.PaymentServiceActivities
+)
+
+const checkoutFailedReason = "checkout failed"
+
+// CheckoutWorkflow moves the order to Pending, validates item prices against the product catalog, reserves stock,
+// charges the customer, marks the order Paid and commits the reserved stock. Declined charge releases stock and leaves
+// the order to the PaymentFailed consumer, any other failure after the order became Pending cancels it,
+// releasing reserved stock and refunding the charge if it may have been made.
+func CheckoutWorkflow(ctx workflow.Context, orderID uuid.UUID) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 5,
+		},
+	})
+
+	err := workflow.ExecuteActivity(ctx, orderServiceActivities.SetOrderStatus, orderID, int(model.Pending)).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var order model.Order
+	err = workflow.ExecuteActivity(ctx, orderServiceActivities.GetOrder, orderID).Get(ctx, &order)
+	if err != nil {
+		return cancelOrder(ctx, orderID, checkoutFailedReason, err)
+	}
+
+	var mismatchedItems []uuid.UUID
+	err = workflow.ExecuteActivity(ctx, productServiceActivities.FindMismatchedItems, order.Items).Get(ctx, &mismatchedItems)
+	if err != nil {
+		return cancelOrder(ctx, orderID, checkoutFailedReason, err)
+	}
+	if len(mismatchedItems) > 0 {
+		workflow.GetLogger(ctx).Info("order items do not match product catalog", "orderID", orderID, "items", mismatchedItems)
+		return cancelOrder(ctx, orderID, "order items do not match product catalog", nil)
+	}
+
+	var reservation activity.ReservationResult
+	err = workflow.ExecuteActivity(ctx, productServiceActivities.ReserveStock, orderID, order.Items).Get(ctx, &reservation)
+	if err != nil {
+		// the stock may have been reserved before the failure
+		return releaseAndCancelOrder(ctx, orderID, err)
+	}
+	if reservation.Rejected {
+		workflow.GetLogger(ctx).Info("stock reservation rejected", "orderID", orderID, "reason", reservation.Reason)
+		return cancelOrder(ctx, orderID, "order items are out of stock", nil)
+	}
+
+	var charge activity.ChargeResult
+	err = workflow.ExecuteActivity(ctx, paymentServiceActivities.ChargeOrder, order.CustomerID, orderID, order.Total()).Get(ctx, &charge)
+	if err != nil {
+		// the charge may have been made before the failure
+		return refundAndCancelOrder(ctx, orderID, err)
+	}
+	if charge.Declined {
+		// PaymentFailed consumer moves the order back to Open, so the customer can top up and check out again
+		workflow.GetLogger(ctx).Info("payment declined", "orderID", orderID, "reason", charge.Reason)
+		return releaseStock(ctx, orderID)
+	}
+
+	err = workflow.ExecuteActivity(ctx, orderServiceActivities.SetOrderStatus, orderID, int(model.Paid)).Get(ctx, nil)
+	if err != nil {
+		return refundAndCancelOrder(ctx, orderID, err)
+	}
+
+	// the order is paid, so failure to commit is not compensated, expired reservation would return the stock back otherwise
+	return workflow.ExecuteActivity(ctx, productServiceActivities.CommitStock, orderID).Get(ctx, nil)
+}
+
+func refundAndCancelOrder(ctx workflow.Context, orderID uuid.UUID, cause error) error {
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	err := workflow.ExecuteActivity(ctx, paymentServiceActivities.RefundOrder, orderID).Get(ctx, nil)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	return releaseAndCancelOrder(ctx, orderID, cause)
+}
+
+func releaseAndCancelOrder(ctx workflow.Context, orderID uuid.UUID, cause error) error {
+	err := releaseStock(ctx, orderID)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	return cancelOrder(ctx, orderID, checkoutFailedReason, cause)
+}
+
+func releaseStock(ctx workflow.Context, orderID uuid.UUID) error {
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	return workflow.ExecuteActivity(ctx, productServiceActivities.ReleaseStock, orderID).Get(ctx, nil)
+}
+
+func cancelOrder(ctx workflow.Context, orderID uuid.UUID, reason string, cause error) error {
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	err := workflow.ExecuteActivity(ctx, orderServiceActivities.CancelOrder, orderID, reason).Get(ctx, nil)
+	return errors.Join(cause, err)
+}
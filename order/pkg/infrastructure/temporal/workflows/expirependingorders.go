@@ -0,0 +1,35 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ExpirePendingOrdersWorkflow runs on cron schedule and cancels orders waiting for payment longer than pendingTTL
+func ExpirePendingOrdersWorkflow(ctx workflow.Context, pendingTTL time.Duration) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 5,
+		},
+	})
+
+	pendingBefore := workflow.Now(ctx).Add(-pendingTTL)
+	var orderIDs []uuid.UUID
+	err := workflow.ExecuteActivity(ctx, orderServiceActivities.FindExpiredPendingOrders, pendingBefore).Get(ctx, &orderIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, orderID := range orderIDs {
+		err = workflow.ExecuteActivity(ctx, orderServiceActivities.ExpirePendingOrder, orderID, pendingBefore).Get(ctx, nil)
+		if err != nil {
+			// the rest of orders are expired anyway, failed one is picked up by the next run
+			workflow.GetLogger(ctx).Warn("failed to expire pending order", "orderID", orderID, "error", err)
+		}
+	}
+	return nil
+}
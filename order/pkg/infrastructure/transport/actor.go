@@ -0,0 +1,25 @@
+package transport
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"order/pkg/application/service"
+)
+
+// ActorMetadataKey is a gRPC metadata key carrying ID of the user on whose behalf the call is made.
+// It is a transport convention, not authentication: the caller sets it as it likes and the value is trusted as is,
+// so the order service must only be reachable by internal callers which put the authenticated user there
+const ActorMetadataKey = "x-actor-id"
+
+// MakeActorServerInterceptor binds actor from call metadata to the context, it is recorded to order history
+func MakeActorServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if values := metadata.ValueFromIncomingContext(ctx, ActorMetadataKey); len(values) > 0 {
+			ctx = service.WithActor(ctx, values[0])
+		}
+		return handler(ctx, req)
+	}
+}
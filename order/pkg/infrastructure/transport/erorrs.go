@@ -5,6 +5,10 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
 )
 
 type errorSet map[error]struct{}
@@ -18,13 +22,45 @@ func newErrorSet(errs ...error) errorSet {
 }
 
 func (s errorSet) Has(err error) bool {
-	_, ok := s[err]
-	return ok
+	if _, ok := s[err]; ok {
+		return true
+	}
+	// typed errors are matched by the sentinel they wrap
+	for e := range s {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	model.ErrInvalidQuantity,
+	model.ErrNegativeAmount,
+	model.ErrCurrencyMismatch,
+	ErrIdempotencyKeyReused,
+	query.ErrInvalidCursor,
+	model.ErrInvalidCancellation,
+)
+
+var notFoundErrorCodes = newErrorSet(
+	model.ErrOrderNotFound,
+	model.ErrOrderItemNotFound,
+	model.ErrProductNotFound,
+	model.ErrPromoCodeNotFound,
+)
+
+var failedPreconditionErrorCodes = newErrorSet(
+	service.ErrInvalidOrderStatus,
+	model.ErrInvalidStatusTransition,
+	model.ErrPromoCodeNotActive,
+	model.ErrPromoCodeUsageLimitExceeded,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var abortedErrorCodes = newErrorSet(
+	model.ErrOrderVersionConflict,
+	ErrRequestInProgress,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +79,10 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isFailedPreconditionError(cause):
+		return codes.FailedPrecondition
+	case isAbortedError(cause):
+		return codes.Aborted
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -69,6 +109,7 @@ func isWarnLevel(err error) bool {
 		codes.InvalidArgument,
 		codes.NotFound,
 		codes.FailedPrecondition,
+		codes.Aborted,
 		codes.Unauthenticated:
 		return true
 	default:
@@ -84,6 +125,14 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isFailedPreconditionError(cause error) bool {
+	return failedPreconditionErrorCodes.Has(cause)
+}
+
+func isAbortedError(cause error) bool {
+	return abortedErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"order/pkg/application/service"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key is already used with another request")
+	ErrRequestInProgress    = errors.New("request with the same idempotency key is in progress")
+)
+
+type idempotentRequest interface {
+	proto.Message
+	GetIdempotencyKey() string
+}
+
+// MakeIdempotencyServerInterceptor replays stored response for requests carrying already used idempotency key.
+// Requests without idempotency key are passed through
+func MakeIdempotencyServerInterceptor(store service.IdempotencyKeyStore, logger *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		request, ok := req.(idempotentRequest)
+		if !ok || request.GetIdempotencyKey() == "" {
+			return handler(ctx, req)
+		}
+		key := request.GetIdempotencyKey()
+
+		requestHash, err := hashRequest(request)
+		if err != nil {
+			return nil, err
+		}
+		record, err := store.Reserve(ctx, info.FullMethod, key, requestHash)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			return replayResponse(record, requestHash)
+		}
+
+		resp, err = handler(ctx, req)
+		// reservation must be finished even if client has gone
+		storeCtx := context.WithoutCancel(ctx)
+		loggerWithFields := logger.WithFields(log.Fields{
+			"route":           info.FullMethod,
+			"idempotency_key": key,
+		})
+		if err != nil {
+			if releaseErr := store.Release(storeCtx, info.FullMethod, key); releaseErr != nil {
+				loggerWithFields.Warnf("failed to release idempotency key: %v", releaseErr)
+			}
+			return nil, err
+		}
+
+		response, marshalErr := marshalResponse(resp)
+		if marshalErr == nil {
+			marshalErr = store.Complete(storeCtx, info.FullMethod, key, response)
+		}
+		if marshalErr != nil {
+			loggerWithFields.Warnf("failed to store response for idempotency key: %v", marshalErr)
+		}
+		return resp, nil
+	}
+}
+
+func replayResponse(record *service.IdempotencyRecord, requestHash string) (interface{}, error) {
+	if record.RequestHash != requestHash {
+		return nil, errors.WithStack(ErrIdempotencyKeyReused)
+	}
+	if record.Response == nil {
+		return nil, errors.WithStack(ErrRequestInProgress)
+	}
+
+	var response anypb.Any
+	err := proto.Unmarshal(record.Response, &response)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	msg, err := response.UnmarshalNew()
+	return msg, errors.WithStack(err)
+}
+
+func marshalResponse(resp interface{}) ([]byte, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type %T", resp)
+	}
+	response, err := anypb.New(msg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	b, err := proto.Marshal(response)
+	return b, errors.WithStack(err)
+}
+
+func hashRequest(request proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -2,15 +2,35 @@ package transport
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	api "order/api/server/orderinternal"
+	"order/pkg/application/query"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/temporal"
 )
 
-func NewInternalAPI() api.OrderInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(
+	orderService service.OrderService,
+	orderQueryService query.OrderQueryService,
+	workflowService temporal.WorkflowService,
+) api.OrderInternalServiceServer {
+	return &internalAPI{
+		orderService:      orderService,
+		orderQueryService: orderQueryService,
+		workflowService:   workflowService,
+	}
 }
 
 type internalAPI struct {
+	orderService      service.OrderService
+	orderQueryService query.OrderQueryService
+	workflowService   temporal.WorkflowService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +38,336 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateOrder(ctx context.Context, request *api.CreateOrderRequest) (*api.CreateOrderResponse, error) {
+	customerID, err := parseUUID(request.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID, err := i.orderService.CreateOrder(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateOrderResponse{
+		OrderID: orderID.String(),
+	}, nil
+}
+
+func (i *internalAPI) DeleteOrder(ctx context.Context, request *api.DeleteOrderRequest) (*api.DeleteOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.DeleteOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteOrderResponse{}, nil
+}
+
+func (i *internalAPI) SetStatus(ctx context.Context, request *api.SetStatusRequest) (*api.SetStatusResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	orderStatus, ok := orderStatusFromAPI[request.Status]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order status %d", request.Status)
+	}
+
+	err = i.orderService.SetStatus(ctx, orderID, orderStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetStatusResponse{}, nil
+}
+
+func (i *internalAPI) CancelOrder(ctx context.Context, request *api.CancelOrderRequest) (*api.CancelOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.CancelOrder(ctx, orderID, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CancelOrderResponse{}, nil
+}
+
+func (i *internalAPI) AddItem(ctx context.Context, request *api.AddItemRequest) (*api.AddItemResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	var variantID uuid.UUID
+	if request.VariantID != "" {
+		variantID, err = parseUUID(request.VariantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	itemID, err := i.orderService.AddItem(ctx, orderID, productID, variantID, int(request.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AddItemResponse{
+		ItemID: itemID.String(),
+	}, nil
+}
+
+func (i *internalAPI) DeleteItem(ctx context.Context, request *api.DeleteItemRequest) (*api.DeleteItemResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	itemID, err := parseUUID(request.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.DeleteItem(ctx, orderID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteItemResponse{}, nil
+}
+
+func (i *internalAPI) ApplyPromoCode(ctx context.Context, request *api.ApplyPromoCodeRequest) (*api.ApplyPromoCodeResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.ApplyPromoCode(ctx, orderID, request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ApplyPromoCodeResponse{}, nil
+}
+
+func (i *internalAPI) GetOrder(ctx context.Context, request *api.GetOrderRequest) (*api.GetOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := i.orderService.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetOrderResponse{
+		Order: orderToAPI(order),
+	}, nil
+}
+
+func (i *internalAPI) ListOrders(ctx context.Context, request *api.ListOrdersRequest) (*api.ListOrdersResponse, error) {
+	spec, err := listOrdersSpecFromAPI(request)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := i.orderQueryService.ListOrders(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.Order, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		result = append(result, orderToAPI(order))
+	}
+	return &api.ListOrdersResponse{
+		Orders:        result,
+		NextPageToken: page.NextCursor,
+	}, nil
+}
+
+func (i *internalAPI) GetOrderHistory(ctx context.Context, request *api.GetOrderHistoryRequest) (*api.GetOrderHistoryResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := i.orderQueryService.GetOrderHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.OrderHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, &api.OrderHistoryEntry{
+			EventType:  entry.EventType,
+			Payload:    entry.Payload,
+			Actor:      entry.Actor,
+			OccurredAt: entry.OccurredAt.Unix(),
+		})
+	}
+	return &api.GetOrderHistoryResponse{
+		Entries: result,
+	}, nil
+}
+
+// Checkout starts the checkout saga, its outcome is observed through the order status
+func (i *internalAPI) Checkout(ctx context.Context, request *api.CheckoutRequest) (*api.CheckoutResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := i.orderService.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(order.Items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "order has no items")
+	}
+	if !order.Status.CanTransitTo(model.Pending) {
+		return nil, model.StatusTransitionError{
+			From: order.Status,
+			To:   model.Pending,
+		}
+	}
+
+	err = i.workflowService.RunCheckoutWorkflow(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CheckoutResponse{}, nil
+}
+
+var orderStatusFromAPI = map[api.OrderStatus]model.OrderStatus{
+	api.OrderStatus_Open:      model.Open,
+	api.OrderStatus_Pending:   model.Pending,
+	api.OrderStatus_Paid:      model.Paid,
+	api.OrderStatus_Cancelled: model.Cancelled,
+}
+
+var orderStatusToAPI = map[model.OrderStatus]api.OrderStatus{
+	model.Open:      api.OrderStatus_Open,
+	model.Pending:   api.OrderStatus_Pending,
+	model.Paid:      api.OrderStatus_Paid,
+	model.Cancelled: api.OrderStatus_Cancelled,
+}
+
+var orderSortFieldFromAPI = map[api.OrderSortField]query.OrderSortField{
+	api.OrderSortField_CreatedAt: query.SortByCreatedAt,
+	api.OrderSortField_UpdatedAt: query.SortByUpdatedAt,
+}
+
+func listOrdersSpecFromAPI(request *api.ListOrdersRequest) (query.ListOrdersSpec, error) {
+	sortBy, ok := orderSortFieldFromAPI[request.SortBy]
+	if !ok {
+		return query.ListOrdersSpec{}, status.Errorf(codes.InvalidArgument, "invalid sort field %d", request.SortBy)
+	}
+	if request.PageSize < 0 {
+		return query.ListOrdersSpec{}, status.Errorf(codes.InvalidArgument, "invalid page size %d", request.PageSize)
+	}
+	spec := query.ListOrdersSpec{
+		SortBy:     sortBy,
+		Descending: request.Descending,
+		Cursor:     request.PageToken,
+		PageSize:   int(request.PageSize),
+	}
+
+	if request.CustomerID != "" {
+		customerID, err := parseUUID(request.CustomerID)
+		if err != nil {
+			return query.ListOrdersSpec{}, err
+		}
+		spec.CustomerID = &customerID
+	}
+	for _, s := range request.Statuses {
+		orderStatus, ok := orderStatusFromAPI[s]
+		if !ok {
+			return query.ListOrdersSpec{}, status.Errorf(codes.InvalidArgument, "invalid order status %d", s)
+		}
+		spec.Statuses = append(spec.Statuses, orderStatus)
+	}
+	if request.CreatedFrom != 0 {
+		createdFrom := time.Unix(request.CreatedFrom, 0)
+		spec.CreatedFrom = &createdFrom
+	}
+	if request.CreatedTo != 0 {
+		createdTo := time.Unix(request.CreatedTo, 0)
+		spec.CreatedTo = &createdTo
+	}
+	return spec, nil
+}
+
+func orderToAPI(order *model.Order) *api.Order {
+	items := make([]*api.Item, 0, len(order.Items))
+	for _, item := range order.Items {
+		var variantID string
+		if item.VariantID != uuid.Nil {
+			variantID = item.VariantID.String()
+		}
+		items = append(items, &api.Item{
+			ItemID:      item.ID.String(),
+			ProductID:   item.ProductID.String(),
+			VariantID:   variantID,
+			ProductName: item.ProductName,
+			Quantity:    int32(item.Quantity),
+			Price:       moneyToAPI(item.Price),
+			Subtotal:    moneyToAPI(item.Subtotal()),
+		})
+	}
+	var promoCode string
+	if order.PromoCode != nil {
+		promoCode = order.PromoCode.Code
+	}
+	return &api.Order{
+		OrderID:              order.ID.String(),
+		CustomerID:           order.CustomerID.String(),
+		Status:               orderStatusToAPI[order.Status],
+		Items:                items,
+		CreatedAt:            order.CreatedAt.Unix(),
+		UpdatedAt:            order.UpdatedAt.Unix(),
+		Total:                moneyToAPI(order.Total()),
+		Cancellation:         cancellationToAPI(order.Cancellation),
+		PaymentFailureReason: order.PaymentFailureReason,
+		PromoCode:            promoCode,
+		Discount:             moneyToAPI(order.Discount()),
+	}
+}
+
+func cancellationToAPI(cancellation *model.Cancellation) *api.Cancellation {
+	if cancellation == nil {
+		return nil
+	}
+	return &api.Cancellation{
+		Reason:      cancellation.Reason,
+		CancelledBy: cancellation.CancelledBy,
+		CancelledAt: cancellation.CancelledAt.Unix(),
+	}
+}
+
+func moneyToAPI(money model.Money) *api.Money {
+	return &api.Money{
+		Amount:   money.Amount,
+		Currency: string(money.Currency),
+	}
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q", value)
+	}
+	return id, nil
+}
@@ -28,6 +28,11 @@ type config struct {
 	DBPassword string `envconfig:"db_password"`
 	DBMaxConn  int    `envconfig:"db_max_conn"`
 
+	AMQPHost           string        `envconfig:"amqp_host" default:"localhost:5672"`
+	AMQPUser           string        `envconfig:"amqp_user"`
+	AMQPPassword       string        `envconfig:"amqp_password"`
+	AMQPConnectTimeout time.Duration `envconfig:"amqp_connect_timeout" default:"30s"`
+
 	TestGRPCAddress string `envconfig:"test_grpc_address" default:"test:8081"`
 }
 
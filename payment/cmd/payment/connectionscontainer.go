@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -47,7 +48,6 @@ func newConnectionsContainer(
 		if err != nil {
 			return fmt.Errorf("failed to init DB for migrations: %w", err)
 		}
-		defer db.Close()
 
 		if err = applyMigrations(db.DB, pathToMigrations); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
@@ -55,6 +55,7 @@ func newConnectionsContainer(
 		log.Infof("Migrations applied successfully")
 		multiCloser.Add(db)
 		container.db = db
+		container.connectionPool = mysql.NewConnectionPool(mysql.NewTransactionalClientFromSQLx(db))
 
 		// TODO: это конекшены к другим сервисам (в данном случае - gRPC)
 		testConnection, err := grpc.NewClient(
@@ -76,6 +77,7 @@ func newConnectionsContainer(
 
 type connectionsContainer struct {
 	db             *sqlx.DB
+	connectionPool mysql.ConnectionPool
 	testConnection grpc.ClientConnInterface
 }
 
@@ -43,12 +43,11 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
 ) error {
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
 
-	// TODO: зарегистрировать свой сервер вместо шаблонного
-	api.RegisterPaymentInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterPaymentInternalServiceServer(grpcServer, transport.NewInternalAPI(container.paymentService))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS account
+(
+    `account_id` VARCHAR(64)    NOT NULL,
+    `user_id`    VARCHAR(64)    NOT NULL,
+    `balance`    DECIMAL(19, 4) NOT NULL,
+    `created_at` DATETIME       NOT NULL,
+    `updated_at` DATETIME       NOT NULL,
+    PRIMARY KEY (`account_id`),
+    UNIQUE INDEX `user_id_idx` (`user_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS payment_transaction
+(
+    `transaction_id` VARCHAR(64)    NOT NULL,
+    `account_id`     VARCHAR(64)    NOT NULL,
+    `order_id`       VARCHAR(64)    NOT NULL,
+    `amount`         DECIMAL(19, 4) NOT NULL,
+    `created_at`     DATETIME       NOT NULL,
+    `refunded_at`    DATETIME,
+    PRIMARY KEY (`transaction_id`),
+    UNIQUE INDEX `order_id_idx` (`order_id`),
+    INDEX `account_id_idx` (`account_id`),
+    CONSTRAINT `payment_transaction_account_id_fk` FOREIGN KEY (`account_id`) REFERENCES account (`account_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"payment/pkg/domain/model"
+	"payment/pkg/domain/service"
+)
+
+type PaymentService interface {
+	CreateAccount(ctx context.Context, userID uuid.UUID, initialBalance float64) (*model.Account, error)
+	ProcessPayment(ctx context.Context, userID, orderID uuid.UUID, attemptID string, amount float64) (*model.Transaction, error)
+	RefundPayment(ctx context.Context, orderID uuid.UUID) (*model.Transaction, error)
+	GetAccountByUserID(ctx context.Context, userID uuid.UUID) (*model.Account, error)
+}
+
+func NewPaymentService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) PaymentService {
+	return &paymentService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type paymentService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *paymentService) CreateAccount(ctx context.Context, userID uuid.UUID, initialBalance float64) (*model.Account, error) {
+	var account *model.Account
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		account, err = s.domainService(ctx, provider).CreateAccount(userID, initialBalance)
+		return err
+	})
+	return account, err
+}
+
+func (s *paymentService) ProcessPayment(ctx context.Context, userID, orderID uuid.UUID, attemptID string, amount float64) (*model.Transaction, error) {
+	var (
+		transaction *model.Transaction
+		paymentErr  error
+	)
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		transaction, err = s.domainService(ctx, provider).ProcessPayment(userID, orderID, attemptID, amount)
+		// PaymentFailed must reach the outbox, so the transaction is committed and the error is returned afterwards
+		if errors.Is(err, model.ErrInsufficientFunds) {
+			paymentErr = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, paymentErr
+}
+
+func (s *paymentService) RefundPayment(ctx context.Context, orderID uuid.UUID) (*model.Transaction, error) {
+	var transaction *model.Transaction
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		transaction, err = s.domainService(ctx, provider).RefundPayment(orderID)
+		return err
+	})
+	return transaction, err
+}
+
+func (s *paymentService) GetAccountByUserID(ctx context.Context, userID uuid.UUID) (*model.Account, error) {
+	var account *model.Account
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		account, err = s.domainService(ctx, provider).GetAccountByUserID(userID)
+		return err
+	})
+	return account, err
+}
+
+func (s *paymentService) domainService(ctx context.Context, provider RepositoryProvider) service.Payment {
+	return service.NewPaymentService(provider.PaymentRepository(ctx), s.domainEventDispatcher(ctx))
+}
+
+func (s *paymentService) domainEventDispatcher(ctx context.Context) service.EventDispatcher {
+	return &domainEventDispatcher{
+		ctx:             ctx,
+		eventDispatcher: s.eventDispatcher,
+	}
+}
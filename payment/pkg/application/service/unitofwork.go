@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"payment/pkg/domain/model"
+)
+
+type RepositoryProvider interface {
+	PaymentRepository(ctx context.Context) model.PaymentRepository
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
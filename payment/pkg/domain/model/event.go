@@ -14,11 +14,24 @@ func (e PaymentSucceeded) Type() string {
 }
 
 type PaymentFailed struct {
-	OrderID uuid.UUID
-	UserID  uuid.UUID
-	Reason  string
+	// TransactionID identifies declined attempt, no transaction is stored for it
+	TransactionID uuid.UUID
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	Reason        string
 }
 
 func (e PaymentFailed) Type() string {
 	return "PaymentFailed"
 }
+
+type PaymentRefunded struct {
+	TransactionID uuid.UUID
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	Amount        float64
+}
+
+func (e PaymentRefunded) Type() string {
+	return "PaymentRefunded"
+}
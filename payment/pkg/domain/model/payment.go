@@ -9,6 +9,7 @@ import (
 
 var (
 	ErrAccountNotFound      = errors.New("user account not found")
+	ErrTransactionNotFound  = errors.New("transaction not found")
 	ErrInsufficientFunds    = errors.New("insufficient funds on account")
 	ErrDuplicateTransaction = errors.New("transaction for this order already exists")
 	ErrNegativeAmount       = errors.New("amount cannot be negative")
@@ -28,12 +29,15 @@ type Transaction struct {
 	OrderID   uuid.UUID
 	Amount    float64
 	Timestamp time.Time
+	// RefundedAt is set once the transaction amount is returned to the account
+	RefundedAt *time.Time
 }
 
 type PaymentRepository interface {
 	NextID() (uuid.UUID, error)
 
 	StoreAccount(account *Account) error
+	FindAccount(id uuid.UUID) (*Account, error)
 	FindAccountByUserID(userID uuid.UUID) (*Account, error)
 
 	StoreTransaction(transaction *Transaction) error
@@ -20,7 +20,10 @@ type EventDispatcher interface {
 
 type Payment interface {
 	CreateAccount(userID uuid.UUID, initialBalance float64) (*model.Account, error)
-	ProcessPayment(userID, orderID uuid.UUID, amount float64) (*model.Transaction, error)
+	// ProcessPayment charges account for the order once, calls with the same attemptID are retries of one attempt
+	// and report its failure under the same transaction ID. Empty attemptID makes every call a separate attempt
+	ProcessPayment(userID, orderID uuid.UUID, attemptID string, amount float64) (*model.Transaction, error)
+	RefundPayment(orderID uuid.UUID) (*model.Transaction, error)
 	GetAccountByUserID(userID uuid.UUID) (*model.Account, error)
 }
 
@@ -56,12 +59,12 @@ func (s *paymentService) CreateAccount(userID uuid.UUID, initialBalance float64)
 	return account, s.repo.StoreAccount(account)
 }
 
-func (s *paymentService) ProcessPayment(userID, orderID uuid.UUID, amount float64) (*model.Transaction, error) {
+func (s *paymentService) ProcessPayment(userID, orderID uuid.UUID, attemptID string, amount float64) (*model.Transaction, error) {
 	if amount <= 0 {
 		return nil, model.ErrNegativeAmount
 	}
 
-	if tx, err := s.repo.FindTransactionByOrderID(orderID); !errors.Is(err, model.ErrDuplicateTransaction) {
+	if tx, err := s.repo.FindTransactionByOrderID(orderID); !errors.Is(err, model.ErrTransactionNotFound) {
 		if err == nil {
 			return tx, nil
 		}
@@ -74,11 +77,19 @@ func (s *paymentService) ProcessPayment(userID, orderID uuid.UUID, amount float6
 	}
 
 	if account.Balance < amount {
-		_ = s.dispatcher.Dispatch(model.PaymentFailed{
-			OrderID: orderID,
-			UserID:  userID,
-			Reason:  "InsufficientFunds",
+		failedTxID, err := s.failedTransactionID(orderID, attemptID)
+		if err != nil {
+			return nil, err
+		}
+		err = s.dispatcher.Dispatch(model.PaymentFailed{
+			TransactionID: failedTxID,
+			OrderID:       orderID,
+			UserID:        userID,
+			Reason:        "InsufficientFunds",
 		})
+		if err != nil {
+			return nil, err
+		}
 		return nil, model.ErrInsufficientFunds
 	}
 
@@ -105,12 +116,62 @@ func (s *paymentService) ProcessPayment(userID, orderID uuid.UUID, amount float6
 		return nil, fmt.Errorf("failed to store transaction: %w", err)
 	}
 
-	_ = s.dispatcher.Dispatch(model.PaymentSucceeded{
+	err = s.dispatcher.Dispatch(model.PaymentSucceeded{
 		TransactionID: transaction.ID,
 		OrderID:       orderID,
 		UserID:        userID,
 		Amount:        amount,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// failedTransactionID derives ID of failed attempt from the order and attempt, so retries of the attempt share it
+func (s *paymentService) failedTransactionID(orderID uuid.UUID, attemptID string) (uuid.UUID, error) {
+	if attemptID == "" {
+		return s.repo.NextID()
+	}
+	return uuid.NewSHA1(orderID, []byte(attemptID)), nil
+}
+
+func (s *paymentService) RefundPayment(orderID uuid.UUID) (*model.Transaction, error) {
+	transaction, err := s.repo.FindTransactionByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.RefundedAt != nil {
+		return transaction, nil
+	}
+
+	account, err := s.repo.FindAccount(transaction.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account.Balance += transaction.Amount
+	account.UpdatedAt = now
+	transaction.RefundedAt = &now
+
+	if err := s.repo.StoreAccount(account); err != nil {
+		return nil, fmt.Errorf("failed to update account balance: %w", err)
+	}
+	if err := s.repo.StoreTransaction(transaction); err != nil {
+		return nil, fmt.Errorf("failed to store transaction: %w", err)
+	}
+
+	err = s.dispatcher.Dispatch(model.PaymentRefunded{
+		TransactionID: transaction.ID,
+		OrderID:       orderID,
+		UserID:        account.UserID,
+		Amount:        transaction.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return transaction, nil
 }
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"payment/pkg/domain/model"
 	"payment/pkg/domain/service"
 	"testing"
@@ -28,6 +29,14 @@ func (m *mockPaymentRepository) StoreAccount(a *model.Account) error {
 	m.accountsByUserID[a.UserID] = a
 	return nil
 }
+func (m *mockPaymentRepository) FindAccount(id uuid.UUID) (*model.Account, error) {
+	for _, a := range m.accountsByUserID {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, model.ErrAccountNotFound
+}
 func (m *mockPaymentRepository) FindAccountByUserID(userID uuid.UUID) (*model.Account, error) {
 	if a, ok := m.accountsByUserID[userID]; ok {
 		return a, nil
@@ -35,7 +44,7 @@ func (m *mockPaymentRepository) FindAccountByUserID(userID uuid.UUID) (*model.Ac
 	return nil, model.ErrAccountNotFound
 }
 func (m *mockPaymentRepository) StoreTransaction(tx *model.Transaction) error {
-	if _, ok := m.txsByOrderID[tx.OrderID]; ok {
+	if existing, ok := m.txsByOrderID[tx.OrderID]; ok && existing.ID != tx.ID {
 		return model.ErrDuplicateTransaction
 	}
 	m.txsByOrderID[tx.OrderID] = tx
@@ -45,14 +54,20 @@ func (m *mockPaymentRepository) FindTransactionByOrderID(orderID uuid.UUID) (*mo
 	if tx, ok := m.txsByOrderID[orderID]; ok {
 		return tx, nil
 	}
-	return nil, model.ErrDuplicateTransaction
+	return nil, model.ErrTransactionNotFound
 }
 
 var _ service.EventDispatcher = (*mockEventDispatcher)(nil)
 
-type mockEventDispatcher struct{ events []service.Event }
+type mockEventDispatcher struct {
+	events []service.Event
+	err    error
+}
 
 func (m *mockEventDispatcher) Dispatch(e service.Event) error {
+	if m.err != nil {
+		return m.err
+	}
 	m.events = append(m.events, e)
 	return nil
 }
@@ -70,7 +85,7 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 		dispatcher.Clear()
 		orderID := uuid.New()
 
-		tx, err := paymentService.ProcessPayment(userID, orderID, 75.0)
+		tx, err := paymentService.ProcessPayment(userID, orderID, "", 75.0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, tx)
@@ -90,7 +105,7 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 		dispatcher.Clear()
 		orderID := uuid.New()
 
-		tx, err := paymentService.ProcessPayment(userID, orderID, 50.0)
+		tx, err := paymentService.ProcessPayment(userID, orderID, "", 50.0)
 
 		require.Error(t, err)
 		assert.ErrorIs(t, err, model.ErrInsufficientFunds)
@@ -103,6 +118,41 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 		event, ok := dispatcher.events[0].(model.PaymentFailed)
 		require.True(t, ok)
 		assert.Equal(t, "InsufficientFunds", event.Reason)
+		assert.Equal(t, orderID, event.OrderID)
+		assert.NotEqual(t, uuid.Nil, event.TransactionID)
+	})
+
+	t.Run("retried failed attempt keeps transaction ID", func(t *testing.T) {
+		dispatcher.Clear()
+		orderID := uuid.New()
+
+		_, err1 := paymentService.ProcessPayment(userID, orderID, "attempt-1", 50.0)
+		_, err2 := paymentService.ProcessPayment(userID, orderID, "attempt-1", 50.0)
+		_, err3 := paymentService.ProcessPayment(userID, orderID, "attempt-2", 50.0)
+
+		assert.ErrorIs(t, err1, model.ErrInsufficientFunds)
+		assert.ErrorIs(t, err2, model.ErrInsufficientFunds)
+		assert.ErrorIs(t, err3, model.ErrInsufficientFunds)
+		require.Len(t, dispatcher.events, 3)
+		first := dispatcher.events[0].(model.PaymentFailed)
+		retried := dispatcher.events[1].(model.PaymentFailed)
+		next := dispatcher.events[2].(model.PaymentFailed)
+		assert.Equal(t, first.TransactionID, retried.TransactionID)
+		assert.NotEqual(t, first.TransactionID, next.TransactionID)
+	})
+
+	t.Run("fails when event dispatch fails", func(t *testing.T) {
+		repo := newMockPaymentRepository()
+		dispatchErr := errors.New("dispatch failed")
+		dispatcher := &mockEventDispatcher{err: dispatchErr}
+		paymentService := service.NewPaymentService(repo, dispatcher)
+		userID := uuid.New()
+		_, _ = paymentService.CreateAccount(userID, 100.0)
+
+		tx, err := paymentService.ProcessPayment(userID, uuid.New(), "", 50.0)
+
+		require.ErrorIs(t, err, dispatchErr)
+		assert.Nil(t, tx)
 	})
 
 	t.Run("idempotency check", func(t *testing.T) {
@@ -113,12 +163,12 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 		_, _ = paymentService.CreateAccount(userID, 200.0)
 		orderID := uuid.New()
 
-		tx1, err1 := paymentService.ProcessPayment(userID, orderID, 50.0)
+		tx1, err1 := paymentService.ProcessPayment(userID, orderID, "", 50.0)
 		require.NoError(t, err1)
 		assert.Equal(t, 150.0, repo.accountsByUserID[userID].Balance)
 		dispatcher.Clear()
 
-		tx2, err2 := paymentService.ProcessPayment(userID, orderID, 50.0)
+		tx2, err2 := paymentService.ProcessPayment(userID, orderID, "", 50.0)
 		require.NoError(t, err2, "Second call should not return an error")
 		assert.Equal(t, 150.0, repo.accountsByUserID[userID].Balance, "Balance should not change on second call")
 		assert.Equal(t, tx1.ID, tx2.ID, "Should return the original transaction")
@@ -127,7 +177,59 @@ func TestPaymentService_ProcessPayment(t *testing.T) {
 
 	t.Run("fails when account not found", func(t *testing.T) {
 		dispatcher.Clear()
-		_, err := paymentService.ProcessPayment(uuid.New(), uuid.New(), 50.0)
+		_, err := paymentService.ProcessPayment(uuid.New(), uuid.New(), "", 50.0)
 		assert.ErrorIs(t, err, model.ErrAccountNotFound)
 	})
 }
+
+func TestPaymentService_RefundPayment(t *testing.T) {
+	repo := newMockPaymentRepository()
+	dispatcher := &mockEventDispatcher{}
+	paymentService := service.NewPaymentService(repo, dispatcher)
+
+	userID := uuid.New()
+	_, _ = paymentService.CreateAccount(userID, 100.0)
+
+	t.Run("successful refund", func(t *testing.T) {
+		orderID := uuid.New()
+		tx, err := paymentService.ProcessPayment(userID, orderID, "", 40.0)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		refunded, err := paymentService.RefundPayment(orderID)
+
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID, refunded.ID)
+		assert.NotNil(t, refunded.RefundedAt)
+		assert.Equal(t, 100.0, repo.accountsByUserID[userID].Balance)
+
+		require.Len(t, dispatcher.events, 1)
+		event, ok := dispatcher.events[0].(model.PaymentRefunded)
+		require.True(t, ok)
+		assert.Equal(t, orderID, event.OrderID)
+		assert.Equal(t, userID, event.UserID)
+		assert.Equal(t, 40.0, event.Amount)
+	})
+
+	t.Run("repeated refund is a no-op", func(t *testing.T) {
+		orderID := uuid.New()
+		_, err := paymentService.ProcessPayment(userID, orderID, "", 40.0)
+		require.NoError(t, err)
+		_, err = paymentService.RefundPayment(orderID)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		_, err = paymentService.RefundPayment(orderID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 100.0, repo.accountsByUserID[userID].Balance, "Balance should not change on second refund")
+		assert.Empty(t, dispatcher.events)
+	})
+
+	t.Run("fails when transaction not found", func(t *testing.T) {
+		dispatcher.Clear()
+		_, err := paymentService.RefundPayment(uuid.New())
+		assert.ErrorIs(t, err, model.ErrTransactionNotFound)
+		assert.Empty(t, dispatcher.events)
+	})
+}
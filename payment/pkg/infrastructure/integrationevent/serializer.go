@@ -0,0 +1,22 @@
+package integrationevent
+
+import (
+	"encoding/json"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/pkg/errors"
+)
+
+func NewEventSerializer() outbox.EventSerializer[outbox.Event] {
+	return &eventSerializer{}
+}
+
+type eventSerializer struct{}
+
+func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to serialize event %q", event.Type())
+	}
+	return string(b), nil
+}
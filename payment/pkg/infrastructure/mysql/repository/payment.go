@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"payment/pkg/domain/model"
+)
+
+func NewPaymentRepository(ctx context.Context, client mysql.ClientContext) model.PaymentRepository {
+	return &paymentRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type paymentRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *paymentRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (p *paymentRepository) StoreAccount(account *model.Account) error {
+	_, err := p.client.ExecContext(p.ctx,
+		`
+	INSERT INTO account (account_id, user_id, balance, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		balance=VALUES(balance),
+		updated_at=VALUES(updated_at)
+	`,
+		account.ID,
+		account.UserID,
+		account.Balance,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (p *paymentRepository) FindAccount(id uuid.UUID) (*model.Account, error) {
+	return p.findAccount(`SELECT account_id, user_id, balance, created_at, updated_at FROM account WHERE account_id = ?`, id)
+}
+
+func (p *paymentRepository) FindAccountByUserID(userID uuid.UUID) (*model.Account, error) {
+	return p.findAccount(`SELECT account_id, user_id, balance, created_at, updated_at FROM account WHERE user_id = ?`, userID)
+}
+
+func (p *paymentRepository) StoreTransaction(transaction *model.Transaction) error {
+	_, err := p.client.ExecContext(p.ctx,
+		`
+	INSERT INTO payment_transaction (transaction_id, account_id, order_id, amount, created_at, refunded_at) VALUES (?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		refunded_at=VALUES(refunded_at)
+	`,
+		transaction.ID,
+		transaction.AccountID,
+		transaction.OrderID,
+		transaction.Amount,
+		transaction.Timestamp,
+		toSQLNull(transaction.RefundedAt),
+	)
+	return errors.WithStack(err)
+}
+
+func (p *paymentRepository) FindTransactionByOrderID(orderID uuid.UUID) (*model.Transaction, error) {
+	var transaction sqlxTransaction
+	err := p.client.GetContext(
+		p.ctx,
+		&transaction,
+		`SELECT transaction_id, account_id, order_id, amount, created_at, refunded_at FROM payment_transaction WHERE order_id = ?`,
+		orderID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrTransactionNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Transaction{
+		ID:         transaction.TransactionID,
+		AccountID:  transaction.AccountID,
+		OrderID:    transaction.OrderID,
+		Amount:     transaction.Amount,
+		Timestamp:  transaction.CreatedAt,
+		RefundedAt: fromSQLNull(transaction.RefundedAt),
+	}, nil
+}
+
+func (p *paymentRepository) findAccount(query string, args ...interface{}) (*model.Account, error) {
+	var account sqlxAccount
+	err := p.client.GetContext(p.ctx, &account, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrAccountNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Account{
+		ID:        account.AccountID,
+		UserID:    account.UserID,
+		Balance:   account.Balance,
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
+	}, nil
+}
+
+type sqlxAccount struct {
+	AccountID uuid.UUID `db:"account_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Balance   float64   `db:"balance"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type sqlxTransaction struct {
+	TransactionID uuid.UUID           `db:"transaction_id"`
+	AccountID     uuid.UUID           `db:"account_id"`
+	OrderID       uuid.UUID           `db:"order_id"`
+	Amount        float64             `db:"amount"`
+	CreatedAt     time.Time           `db:"created_at"`
+	RefundedAt    sql.Null[time.Time] `db:"refunded_at"`
+}
+
+func fromSQLNull[T any](v sql.Null[T]) *T {
+	if v.Valid {
+		return &v.V
+	}
+	return nil
+}
+
+func toSQLNull[T any](v *T) sql.Null[T] {
+	if v == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{
+		V:     *v,
+		Valid: true,
+	}
+}
@@ -0,0 +1,23 @@
+package mysql
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+
+	"payment/pkg/application/service"
+	"payment/pkg/domain/model"
+	"payment/pkg/infrastructure/mysql/repository"
+)
+
+func NewRepositoryProvider(client mysql.ClientContext) service.RepositoryProvider {
+	return &repositoryProvider{client: client}
+}
+
+type repositoryProvider struct {
+	client mysql.ClientContext
+}
+
+func (r *repositoryProvider) PaymentRepository(ctx context.Context) model.PaymentRepository {
+	return repository.NewPaymentRepository(ctx, r.client)
+}
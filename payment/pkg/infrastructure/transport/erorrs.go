@@ -5,6 +5,8 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	"payment/pkg/domain/model"
 )
 
 type errorSet map[error]struct{}
@@ -18,13 +20,30 @@ func newErrorSet(errs ...error) errorSet {
 }
 
 func (s errorSet) Has(err error) bool {
-	_, ok := s[err]
-	return ok
+	if _, ok := s[err]; ok {
+		return true
+	}
+	// domain errors wrapped with fmt.Errorf are matched by the sentinel they wrap
+	for e := range s {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	model.ErrNegativeAmount,
+)
+
+var notFoundErrorCodes = newErrorSet(
+	model.ErrAccountNotFound,
+	model.ErrTransactionNotFound,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var failedPreconditionErrorCodes = newErrorSet(
+	model.ErrInsufficientFunds,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +62,8 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isFailedPreconditionError(cause):
+		return codes.FailedPrecondition
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -84,6 +105,10 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isFailedPreconditionError(cause error) bool {
+	return failedPreconditionErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
@@ -3,14 +3,23 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	api "payment/api/server/paymentinternal"
+	"payment/pkg/application/service"
+	"payment/pkg/domain/model"
 )
 
-func NewInternalAPI() api.PaymentInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(paymentService service.PaymentService) api.PaymentInternalServiceServer {
+	return &internalAPI{
+		paymentService: paymentService,
+	}
 }
 
 type internalAPI struct {
+	paymentService service.PaymentService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +27,101 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateAccount(ctx context.Context, request *api.CreateAccountRequest) (*api.CreateAccountResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := i.paymentService.CreateAccount(ctx, userID, request.InitialBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateAccountResponse{
+		Account: accountToAPI(account),
+	}, nil
+}
+
+func (i *internalAPI) GetAccount(ctx context.Context, request *api.GetAccountRequest) (*api.GetAccountResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := i.paymentService.GetAccountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetAccountResponse{
+		Account: accountToAPI(account),
+	}, nil
+}
+
+func (i *internalAPI) ProcessPayment(ctx context.Context, request *api.ProcessPaymentRequest) (*api.ProcessPaymentResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := i.paymentService.ProcessPayment(ctx, userID, orderID, request.AttemptID, request.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ProcessPaymentResponse{
+		Transaction: transactionToAPI(transaction),
+	}, nil
+}
+
+func (i *internalAPI) RefundPayment(ctx context.Context, request *api.RefundPaymentRequest) (*api.RefundPaymentResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := i.paymentService.RefundPayment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RefundPaymentResponse{
+		Transaction: transactionToAPI(transaction),
+	}, nil
+}
+
+func accountToAPI(account *model.Account) *api.Account {
+	return &api.Account{
+		AccountID: account.ID.String(),
+		UserID:    account.UserID.String(),
+		Balance:   account.Balance,
+	}
+}
+
+func transactionToAPI(transaction *model.Transaction) *api.Transaction {
+	result := &api.Transaction{
+		TransactionID: transaction.ID.String(),
+		AccountID:     transaction.AccountID.String(),
+		OrderID:       transaction.OrderID.String(),
+		Amount:        transaction.Amount,
+		CreatedAt:     transaction.Timestamp.Unix(),
+	}
+	if transaction.RefundedAt != nil {
+		result.RefundedAt = transaction.RefundedAt.Unix()
+	}
+	return result
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q", value)
+	}
+	return id, nil
+}
@@ -28,7 +28,19 @@ type config struct {
 	DBPassword string `envconfig:"db_password"`
 	DBMaxConn  int    `envconfig:"db_max_conn"`
 
+	AMQPHost           string        `envconfig:"amqp_host" default:"localhost:5672"`
+	AMQPUser           string        `envconfig:"amqp_user"`
+	AMQPPassword       string        `envconfig:"amqp_password"`
+	AMQPConnectTimeout time.Duration `envconfig:"amqp_connect_timeout" default:"30s"`
+
 	TestGRPCAddress string `envconfig:"test_grpc_address" default:"test:8081"`
+
+	// ReservationTTL is a time stock stays reserved for order unless committed
+	ReservationTTL time.Duration `envconfig:"reservation_ttl" default:"15m"`
+	// ReservationExpirationInterval is a period of checking for expired reservations
+	ReservationExpirationInterval time.Duration `envconfig:"reservation_expiration_interval" default:"1m"`
+	// PriceChangeInterval is a period of checking for due scheduled price changes
+	PriceChangeInterval time.Duration `envconfig:"price_change_interval" default:"1m"`
 }
 
 func (c *config) buildDSN() string {
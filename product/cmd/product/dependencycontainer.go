@@ -1,18 +1,43 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
 
-// TODO: добавить зависимости
+	"product/pkg/application/query"
+	appservice "product/pkg/application/service"
+	"product/pkg/infrastructure/integrationevent"
+	inframysql "product/pkg/infrastructure/mysql"
+	mysqlquery "product/pkg/infrastructure/mysql/query"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	libUoW := mysql.NewUnitOfWork(connContainer.connectionPool, inframysql.NewRepositoryProvider)
+	uow := inframysql.NewUnitOfWork(libUoW)
+	eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		productService:       appservice.NewProductService(uow, eventDispatcher),
+		productQueryService:  mysqlquery.NewProductQueryService(connContainer.db),
+		stockService:         appservice.NewStockService(uow, eventDispatcher, config.ReservationTTL),
+		categoryService:      appservice.NewCategoryService(uow, eventDispatcher),
+		categoryQueryService: mysqlquery.NewCategoryQueryService(connContainer.db),
+		priceService:         appservice.NewPriceService(uow, eventDispatcher),
+		priceQueryService:    mysqlquery.NewPriceQueryService(connContainer.db),
+		variantService:       appservice.NewVariantService(uow, eventDispatcher),
 	}, nil
 }
 
 type dependencyContainer struct {
-	db *sqlx.DB
+	productService       appservice.ProductService
+	productQueryService  query.ProductQueryService
+	stockService         appservice.StockService
+	categoryService      appservice.CategoryService
+	categoryQueryService query.CategoryQueryService
+	priceService         appservice.PriceService
+	priceQueryService    query.PriceQueryService
+	variantService       appservice.VariantService
 }
@@ -57,6 +57,11 @@ func runApp(
 		Name: appID,
 		Commands: []*cli.Command{
 			service(config, logger, closer),
+			messageHandler(config, logger, closer),
+			reservationExpirer(config, logger, closer),
+			priceScheduler(config, logger, closer),
+			importProducts(config, logger, closer),
+			exportProducts(config, logger, closer),
 			migrate(config, logger),
 		},
 	}
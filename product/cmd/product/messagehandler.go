@@ -0,0 +1,59 @@
+package main
+
+import (
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"product/pkg/infrastructure/integrationevent"
+)
+
+func messageHandler(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "message-handler",
+		Usage: "Publishes stored domain events to AMQP",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			libLogger := newLibLogger(logger)
+			amqpConnection := amqp.NewAMQPConnection(appID, &amqp.ConnectionConfig{
+				User:           config.AMQPUser,
+				Password:       config.AMQPPassword,
+				Host:           config.AMQPHost,
+				ConnectTimeout: config.AMQPConnectTimeout,
+			}, libLogger)
+			amqpEventProducer := amqpConnection.Producer(
+				&amqp.ExchangeConfig{
+					Name:    integrationevent.ExchangeName,
+					Kind:    integrationevent.ExchangeKind,
+					Durable: true,
+				},
+				nil,
+				nil,
+			)
+			err = amqpConnection.Start()
+			if err != nil {
+				return errors.Wrap(err, "failed to start AMQP connection")
+			}
+			closer.Add(libio.CloserFunc(amqpConnection.Stop))
+
+			outboxEventHandler := outbox.NewEventHandler(outbox.EventHandlerConfig{
+				TransportName:  integrationevent.TransportName,
+				Transport:      integrationevent.NewOutboxTransport(libLogger, amqpEventProducer),
+				ConnectionPool: connContainer.connectionPool,
+				Logger:         libLogger,
+			})
+			return outboxEventHandler.Start(c.Context)
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+func priceScheduler(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "price-scheduler",
+		Usage: "Periodically applies scheduled price changes which have come into effect",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			container, err := newDependencyContainer(config, connContainer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init dependencies")
+			}
+
+			ticker := time.NewTicker(config.PriceChangeInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-c.Context.Done():
+					return nil
+				case <-ticker.C:
+					applied, err := container.priceService.ApplyDuePriceChanges(c.Context)
+					if err != nil {
+						// next tick retries, applied changes are removed so they are not applied twice
+						logger.WithError(err).Error("failed to apply scheduled price changes")
+					}
+					if applied > 0 {
+						logger.Infof("Applied %d scheduled price changes", applied)
+					}
+				}
+			}
+		},
+	}
+}
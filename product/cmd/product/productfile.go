@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"product/pkg/application/query"
+	appservice "product/pkg/application/service"
+	"product/pkg/domain/model"
+	domainservice "product/pkg/domain/service"
+	"product/pkg/infrastructure/productfile"
+)
+
+var upsertActionNames = map[domainservice.UpsertAction]string{
+	domainservice.UpsertCreated:   "created",
+	domainservice.UpsertUpdated:   "updated",
+	domainservice.UpsertUnchanged: "unchanged",
+}
+
+func importProducts(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Creates products or updates prices of existing ones by name from CSV or JSON file",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "format", Usage: "csv or json, taken from file extension by default"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "validate rows without changing products"},
+		},
+		Action: func(c *cli.Context) error {
+			path := c.Args().First()
+			if path == "" {
+				return pkgerrors.New("file is required")
+			}
+			format, err := productfile.ParseFormat(c.String("format"), path)
+			if err != nil {
+				return err
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return pkgerrors.WithStack(err)
+			}
+			defer file.Close()
+			reader, err := productfile.NewReader(format, file)
+			if err != nil {
+				return err
+			}
+
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return pkgerrors.Wrap(err, "failed to init connections")
+			}
+			container, err := newDependencyContainer(config, connContainer)
+			if err != nil {
+				return pkgerrors.Wrap(err, "failed to init dependencies")
+			}
+
+			dryRun := c.Bool("dry-run")
+			counts := make(map[string]int)
+			session := container.productService.NewImportSession()
+			for {
+				row, err := reader.Read()
+				if err == io.EOF {
+					break
+				}
+				var rowErr *productfile.RowError
+				if errors.As(err, &rowErr) {
+					logger.WithField("line", rowErr.Line).Warnf("row failed: %v", rowErr.Err)
+					counts["failed"]++
+					continue
+				}
+				if err != nil {
+					return err
+				}
+
+				_, action, err := session.Import(c.Context, row.Name, row.Price, dryRun)
+				if err != nil {
+					if !appservice.IsImportRowError(err) {
+						return pkgerrors.Wrapf(err, "failed to import line %d", row.Line)
+					}
+					logger.WithField("line", row.Line).Warnf("row failed: %v", err)
+					counts["failed"]++
+					continue
+				}
+				counts[upsertActionNames[action]]++
+			}
+
+			logger.WithFields(log.Fields{
+				"dryRun":    dryRun,
+				"created":   counts["created"],
+				"updated":   counts["updated"],
+				"unchanged": counts["unchanged"],
+				"failed":    counts["failed"],
+			}).Infof("Import finished")
+			if counts["failed"] > 0 {
+				return pkgerrors.Errorf("%d rows failed", counts["failed"])
+			}
+			return nil
+		},
+	}
+}
+
+func exportProducts(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Writes all products to CSV or JSON file",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "format", Usage: "csv or json, taken from file extension by default"},
+			&cli.BoolFlag{Name: "include-deleted", Usage: "export soft deleted products too"},
+		},
+		Action: func(c *cli.Context) error {
+			path := c.Args().First()
+			if path == "" {
+				return pkgerrors.New("file is required")
+			}
+			format, err := productfile.ParseFormat(c.String("format"), path)
+			if err != nil {
+				return err
+			}
+
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return pkgerrors.Wrap(err, "failed to init connections")
+			}
+			container, err := newDependencyContainer(config, connContainer)
+			if err != nil {
+				return pkgerrors.Wrap(err, "failed to init dependencies")
+			}
+
+			file, err := os.Create(path)
+			if err != nil {
+				return pkgerrors.WithStack(err)
+			}
+			defer file.Close()
+			writer, err := productfile.NewWriter(format, file)
+			if err != nil {
+				return err
+			}
+
+			exported := 0
+			spec := query.ListProductsSpec{IncludeDeleted: c.Bool("include-deleted")}
+			err = query.ForEachProduct(c.Context, container.productQueryService, spec, func(product *model.Product) error {
+				exported++
+				return writer.Write(product)
+			})
+			if err != nil {
+				return err
+			}
+			if err = writer.Close(); err != nil {
+				return err
+			}
+			if err = file.Close(); err != nil {
+				return pkgerrors.WithStack(err)
+			}
+
+			logger.Infof("Exported %d products", exported)
+			return nil
+		},
+	}
+}
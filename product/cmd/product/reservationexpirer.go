@@ -0,0 +1,50 @@
+package main
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+func reservationExpirer(
+	config *config,
+	logger *log.Logger,
+	closer *multiCloser,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "reservation-expirer",
+		Usage: "Periodically returns units of expired stock reservations to stock",
+		Action: func(c *cli.Context) error {
+			connContainer, err := newConnectionsContainer(config, logger, closer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init connections")
+			}
+
+			container, err := newDependencyContainer(config, connContainer)
+			if err != nil {
+				return errors.Wrap(err, "failed to init dependencies")
+			}
+
+			ticker := time.NewTicker(config.ReservationExpirationInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-c.Context.Done():
+					return nil
+				case <-ticker.C:
+					released, err := container.stockService.ReleaseExpiredReservations(c.Context)
+					if err != nil {
+						// next tick retries, reservations are released idempotently
+						logger.WithError(err).Error("failed to release expired reservations")
+						continue
+					}
+					if released > 0 {
+						logger.Infof("Released expired reservations of %d orders", released)
+					}
+				}
+			}
+		},
+	}
+}
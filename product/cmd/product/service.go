@@ -43,12 +43,23 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
 ) error {
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)),
+		grpc.StreamInterceptor(makeGrpcStreamInterceptor(logger)),
+	)
 
-	// TODO: зарегистрировать свой сервер вместо шаблонного
-	api.RegisterProductInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterProductInternalServiceServer(grpcServer, transport.NewInternalAPI(
+		container.productService,
+		container.productQueryService,
+		container.stockService,
+		container.categoryService,
+		container.categoryQueryService,
+		container.priceService,
+		container.priceQueryService,
+		container.variantService,
+	))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
@@ -95,3 +106,12 @@ func makeGrpcUnaryInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
 		return resp, errorInterceptor.TranslateGRPCError(err)
 	}
 }
+
+func makeGrpcStreamInterceptor(logger *log.Logger) grpc.StreamServerInterceptor {
+	loggerInterceptor := transport.MakeLoggerStreamServerInterceptor(logger)
+	errorInterceptor := transport.ErrorInterceptor{Logger: logger}
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := loggerInterceptor(srv, stream, info, handler)
+		return errorInterceptor.TranslateGRPCError(err)
+	}
+}
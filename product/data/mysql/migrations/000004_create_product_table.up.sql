@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS product
+(
+    `product_id`  VARCHAR(64)    NOT NULL,
+    `name`        VARCHAR(255)   NOT NULL,
+    `price`       DECIMAL(19, 4) NOT NULL,
+    `created_at`  DATETIME       NOT NULL,
+    `updated_at`  DATETIME       NOT NULL,
+    `deleted_at`  DATETIME,
+    -- only names of not deleted products must be unique, NULLs do not collide in unique index
+    `active_name` VARCHAR(255) AS (IF(`deleted_at` IS NULL, `name`, NULL)) STORED,
+    PRIMARY KEY (`product_id`),
+    UNIQUE INDEX `active_name_uidx` (`active_name`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
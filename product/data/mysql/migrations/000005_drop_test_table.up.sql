@@ -0,0 +1,4 @@
+-- unlike order service, placeholder table of 000001 is dropped here instead of rewriting 000001:
+-- outbox migrations 000002 and 000003 were already applied on top of it, and rewritten 000001
+-- would never run on such databases, leaving them without product table
+DROP TABLE IF EXISTS test_table;
@@ -0,0 +1,5 @@
+ALTER TABLE product
+    DROP INDEX `name_idx`,
+    DROP INDEX `price_idx`,
+    DROP INDEX `created_at_idx`
+;
@@ -0,0 +1,5 @@
+ALTER TABLE product
+    ADD INDEX `name_idx` (`name`),
+    ADD INDEX `price_idx` (`price`),
+    ADD INDEX `created_at_idx` (`created_at`)
+;
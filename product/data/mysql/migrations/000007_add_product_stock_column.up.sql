@@ -0,0 +1,3 @@
+ALTER TABLE product
+    ADD COLUMN `stock` INT NOT NULL DEFAULT 0
+;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_reservation
+(
+    `order_id`   VARCHAR(64) NOT NULL,
+    `product_id` VARCHAR(64) NOT NULL,
+    `quantity`   INT         NOT NULL,
+    `status`     INT         NOT NULL,
+    `created_at` DATETIME    NOT NULL,
+    `expires_at` DATETIME    NOT NULL,
+    PRIMARY KEY (`order_id`, `product_id`),
+    INDEX `status_expires_at_idx` (`status`, `expires_at`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS category
+(
+    `category_id` VARCHAR(64)  NOT NULL,
+    `name`        VARCHAR(255) NOT NULL,
+    `parent_id`   VARCHAR(64),
+    `created_at`  DATETIME     NOT NULL,
+    `updated_at`  DATETIME     NOT NULL,
+    PRIMARY KEY (`category_id`),
+    INDEX `parent_id_idx` (`parent_id`),
+    CONSTRAINT `category_parent_id_fk` FOREIGN KEY (`parent_id`) REFERENCES category (`category_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
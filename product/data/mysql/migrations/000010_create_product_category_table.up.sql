@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS product_category
+(
+    `product_id`  VARCHAR(64) NOT NULL,
+    `category_id` VARCHAR(64) NOT NULL,
+    PRIMARY KEY (`product_id`, `category_id`),
+    INDEX `category_id_idx` (`category_id`),
+    CONSTRAINT `product_category_product_id_fk` FOREIGN KEY (`product_id`) REFERENCES product (`product_id`) ON DELETE CASCADE,
+    CONSTRAINT `product_category_category_id_fk` FOREIGN KEY (`category_id`) REFERENCES category (`category_id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS price_history
+(
+    `price_history_id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `product_id`       VARCHAR(64)     NOT NULL,
+    `price`            DECIMAL(19, 4)  NOT NULL,
+    `effective_from`   DATETIME        NOT NULL,
+    PRIMARY KEY (`price_history_id`),
+    INDEX `product_id_effective_from_idx` (`product_id`, `effective_from`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
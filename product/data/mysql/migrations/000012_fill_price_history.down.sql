@@ -0,0 +1 @@
+DELETE FROM price_history;
@@ -0,0 +1,5 @@
+-- moment of the last price change of existing products is unknown, so their current price is known to be effective
+-- only since their last update
+INSERT INTO price_history (product_id, price, effective_from)
+SELECT product_id, price, updated_at FROM product
+;
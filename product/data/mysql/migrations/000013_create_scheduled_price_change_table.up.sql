@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS scheduled_price_change
+(
+    `price_change_id` VARCHAR(64)    NOT NULL,
+    `product_id`      VARCHAR(64)    NOT NULL,
+    `price`           DECIMAL(19, 4) NOT NULL,
+    `effective_at`    DATETIME       NOT NULL,
+    `created_at`      DATETIME       NOT NULL,
+    PRIMARY KEY (`price_change_id`),
+    INDEX `effective_at_idx` (`effective_at`),
+    INDEX `product_id_effective_at_idx` (`product_id`, `effective_at`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
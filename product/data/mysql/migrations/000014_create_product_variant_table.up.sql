@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS product_variant
+(
+    `variant_id` VARCHAR(64)    NOT NULL,
+    `product_id` VARCHAR(64)    NOT NULL,
+    `sku`        VARCHAR(255)   NOT NULL,
+    `attributes` JSON           NOT NULL,
+    -- NULL price means price of product is used
+    `price`      DECIMAL(19, 4),
+    `stock`      INT            NOT NULL DEFAULT 0,
+    `created_at` DATETIME       NOT NULL,
+    `updated_at` DATETIME       NOT NULL,
+    `deleted_at` DATETIME,
+    -- only skus of not deleted variants must be unique, NULLs do not collide in unique index
+    `active_sku` VARCHAR(255) AS (IF(`deleted_at` IS NULL, `sku`, NULL)) STORED,
+    PRIMARY KEY (`variant_id`),
+    UNIQUE INDEX `active_sku_uidx` (`active_sku`),
+    INDEX `product_id_idx` (`product_id`),
+    CONSTRAINT `product_variant_product_id_fk` FOREIGN KEY (`product_id`) REFERENCES product (`product_id`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci
+;
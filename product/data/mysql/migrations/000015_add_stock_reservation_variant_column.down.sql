@@ -0,0 +1,5 @@
+ALTER TABLE stock_reservation
+    DROP PRIMARY KEY,
+    DROP COLUMN `variant_id`,
+    ADD PRIMARY KEY (`order_id`, `product_id`)
+;
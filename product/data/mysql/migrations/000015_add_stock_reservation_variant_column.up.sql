@@ -0,0 +1,6 @@
+-- nil uuid stands for reservation of product itself, so it can be a part of primary key
+ALTER TABLE stock_reservation
+    ADD COLUMN `variant_id` VARCHAR(64) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' AFTER `product_id`,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (`order_id`, `product_id`, `variant_id`)
+;
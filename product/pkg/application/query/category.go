@@ -0,0 +1,12 @@
+package query
+
+import (
+	"context"
+
+	"product/pkg/domain/model"
+)
+
+type CategoryQueryService interface {
+	// ListCategories returns all categories, parents precede their children
+	ListCategories(ctx context.Context) ([]*model.Category, error)
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+)
+
+var ErrPriceNotFound = errors.New("product has no price effective at the given moment")
+
+type PriceQueryService interface {
+	// GetPriceAt returns price history entry of product effective at the given moment
+	GetPriceAt(ctx context.Context, productID uuid.UUID, at time.Time) (*model.PriceHistoryEntry, error)
+	// ListScheduledPriceChanges returns not yet applied price changes of product, earliest first
+	ListScheduledPriceChanges(ctx context.Context, productID uuid.UUID) ([]*model.ScheduledPriceChange, error)
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type ProductSortField int
+
+const (
+	SortByName ProductSortField = iota
+	SortByPrice
+	SortByCreatedAt
+)
+
+// ListProductsSpec filters products, zero values of fields mean no filtering
+type ListProductsSpec struct {
+	NamePrefix   string
+	NameContains string
+	// MinPrice and MaxPrice are inclusive bounds of product price
+	MinPrice *float64
+	MaxPrice *float64
+	// IncludeDeleted adds soft deleted products to the result
+	IncludeDeleted bool
+	// CategoryID selects products assigned to the category or any of its descendants
+	CategoryID *uuid.UUID
+
+	SortBy     ProductSortField
+	Descending bool
+
+	// Cursor is taken from ProductPage.NextCursor of the previous page and must be used with the same spec
+	Cursor   string
+	PageSize int
+}
+
+type ProductPage struct {
+	Products []*model.Product
+	// NextCursor is empty for the last page
+	NextCursor string
+}
+
+type ProductQueryService interface {
+	ListProducts(ctx context.Context, spec ListProductsSpec) (ProductPage, error)
+}
+
+// ForEachProduct calls f for every product matching spec walking through all pages
+func ForEachProduct(ctx context.Context, queryService ProductQueryService, spec ListProductsSpec, f func(product *model.Product) error) error {
+	spec.PageSize = MaxPageSize
+	for {
+		page, err := queryService.ListProducts(ctx, spec)
+		if err != nil {
+			return err
+		}
+		for _, product := range page.Products {
+			if err = f(product); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		spec.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, name string, parentID *uuid.UUID) (*model.Category, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, name string, parentID *uuid.UUID) (*model.Category, error)
+	AssignProduct(ctx context.Context, categoryID, productID uuid.UUID) error
+	UnassignProduct(ctx context.Context, categoryID, productID uuid.UUID) error
+}
+
+func NewCategoryService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) CategoryService {
+	return &categoryService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type categoryService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, name string, parentID *uuid.UUID) (*model.Category, error) {
+	var category *model.Category
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		category, err = s.domainService(ctx, provider).CreateCategory(name, parentID)
+		return err
+	})
+	return category, err
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, id uuid.UUID, name string, parentID *uuid.UUID) (*model.Category, error) {
+	var category *model.Category
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		category, err = s.domainService(ctx, provider).UpdateCategory(id, name, parentID)
+		return err
+	})
+	return category, err
+}
+
+func (s *categoryService) AssignProduct(ctx context.Context, categoryID, productID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).AssignProduct(categoryID, productID)
+	})
+}
+
+func (s *categoryService) UnassignProduct(ctx context.Context, categoryID, productID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).UnassignProduct(categoryID, productID)
+	})
+}
+
+func (s *categoryService) domainService(ctx context.Context, provider RepositoryProvider) service.Category {
+	return service.NewCategoryService(
+		provider.CategoryRepository(ctx),
+		provider.ProductRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+// duePriceChangesBatchSize bounds number of price changes applied by one ApplyDuePriceChanges call
+const duePriceChangesBatchSize = 100
+
+type PriceService interface {
+	SchedulePriceChange(ctx context.Context, productID uuid.UUID, price float64, effectiveAt time.Time) (*model.ScheduledPriceChange, error)
+	CancelPriceChange(ctx context.Context, id uuid.UUID) error
+	// ApplyDuePriceChanges returns number of price changes which have been applied.
+	// Change which fails to apply is skipped, so it does not block later ones, its error is joined into the returned error
+	ApplyDuePriceChanges(ctx context.Context) (int, error)
+}
+
+func NewPriceService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) PriceService {
+	return &priceService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type priceService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *priceService) SchedulePriceChange(ctx context.Context, productID uuid.UUID, price float64, effectiveAt time.Time) (*model.ScheduledPriceChange, error) {
+	var change *model.ScheduledPriceChange
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		change, err = s.domainService(ctx, provider).SchedulePriceChange(productID, price, effectiveAt)
+		return err
+	})
+	return change, err
+}
+
+func (s *priceService) CancelPriceChange(ctx context.Context, id uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).CancelPriceChange(id)
+	})
+}
+
+func (s *priceService) ApplyDuePriceChanges(ctx context.Context) (int, error) {
+	now := time.Now()
+	var changeIDs []uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		changeIDs, err = provider.ScheduledPriceChangeRepository(ctx).FindDue(now, duePriceChangesBatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// changes are applied in separate transactions to keep product locks short
+	applied := 0
+	var errs []error
+	for _, changeID := range changeIDs {
+		var changeApplied bool
+		err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+			var err error
+			changeApplied, err = s.domainService(ctx, provider).ApplyPriceChange(changeID, now)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply price change %s: %w", changeID, err))
+			continue
+		}
+		if changeApplied {
+			applied++
+		}
+	}
+	return applied, errors.Join(errs...)
+}
+
+func (s *priceService) domainService(ctx context.Context, provider RepositoryProvider) service.Price {
+	return service.NewPriceService(
+		provider.ProductRepository(ctx),
+		provider.PriceHistoryRepository(ctx),
+		provider.ScheduledPriceChangeRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+type ProductService interface {
+	CreateProduct(ctx context.Context, name string, price float64) (*model.Product, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, name string, price float64) (*model.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	GetProduct(ctx context.Context, id uuid.UUID) (*model.Product, error)
+	// NewImportSession starts import of one file, rows of the file are imported through the session in order
+	NewImportSession() ImportSession
+}
+
+type ImportSession interface {
+	// Import upserts product by name, with dryRun changes are validated and rolled back.
+	// Product is nil if it would be created by the row or an earlier dry run row of the session
+	Import(ctx context.Context, name string, price float64, dryRun bool) (*model.Product, service.UpsertAction, error)
+}
+
+// errDryRun rolls back transaction of dry run import
+var errDryRun = errors.New("dry run")
+
+// IsImportRowError reports whether err is caused by imported row itself, so import may go on with other rows
+func IsImportRowError(err error) bool {
+	return errors.Is(err, model.ErrProductNameRequired) ||
+		errors.Is(err, model.ErrProductPriceInvalid) ||
+		errors.Is(err, model.ErrProductNameExists)
+}
+
+func NewProductService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) ProductService {
+	return &productService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type productService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *productService) CreateProduct(ctx context.Context, name string, price float64) (*model.Product, error) {
+	var product *model.Product
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		product, err = s.domainService(ctx, provider).CreateProduct(name, price)
+		return err
+	})
+	return product, err
+}
+
+func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, name string, price float64) (*model.Product, error) {
+	var product *model.Product
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		product, err = s.domainService(ctx, provider).UpdateProduct(id, name, price)
+		return err
+	})
+	return product, err
+}
+
+func (s *productService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteProduct(id)
+	})
+}
+
+func (s *productService) GetProduct(ctx context.Context, id uuid.UUID) (*model.Product, error) {
+	var product *model.Product
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		product, err = s.domainService(ctx, provider).GetProduct(id)
+		return err
+	})
+	return product, err
+}
+
+func (s *productService) NewImportSession() ImportSession {
+	return &importSession{
+		products:       s,
+		dryRunProducts: make(map[string]dryRunProduct),
+	}
+}
+
+func (s *productService) importProduct(ctx context.Context, name string, price float64, dryRun bool) (*model.Product, service.UpsertAction, error) {
+	var (
+		product *model.Product
+		action  service.UpsertAction
+	)
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		product, action, err = s.domainService(ctx, provider).UpsertProduct(name, price)
+		if err == nil && dryRun {
+			return errDryRun
+		}
+		return err
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	return product, action, err
+}
+
+// importSession remembers products of dry run rows, since every dry run row is rolled back
+// and later rows with the same name would not see it otherwise
+type importSession struct {
+	products *productService
+	// dryRunProducts are keyed by lowercased name, as product names are compared case-insensitively
+	dryRunProducts map[string]dryRunProduct
+}
+
+type dryRunProduct struct {
+	// product is nil if it has been created by dry run
+	product *model.Product
+	price   float64
+}
+
+func (s *importSession) Import(ctx context.Context, name string, price float64, dryRun bool) (*model.Product, service.UpsertAction, error) {
+	product, action, err := s.products.importProduct(ctx, name, price, dryRun)
+	if err != nil || !dryRun {
+		return product, action, err
+	}
+
+	key := strings.ToLower(name)
+	if seen, ok := s.dryRunProducts[key]; ok {
+		product = seen.product
+		action = service.UpsertUpdated
+		if seen.price == price {
+			action = service.UpsertUnchanged
+		}
+	} else if action == service.UpsertCreated {
+		product = nil
+	}
+	s.dryRunProducts[key] = dryRunProduct{product: product, price: price}
+	return product, action, nil
+}
+
+func (s *productService) domainService(ctx context.Context, provider RepositoryProvider) service.Product {
+	return service.NewProductService(
+		provider.ProductRepository(ctx),
+		provider.PriceHistoryRepository(ctx),
+		s.domainEventDispatcher(ctx),
+	)
+}
+
+func (s *productService) domainEventDispatcher(ctx context.Context) service.EventDispatcher {
+	return &domainEventDispatcher{
+		ctx:             ctx,
+		eventDispatcher: s.eventDispatcher,
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+// expiredReservationsBatchSize bounds number of orders released by one ReleaseExpiredReservations call
+const expiredReservationsBatchSize = 100
+
+type StockService interface {
+	SetStock(ctx context.Context, productID uuid.UUID, quantity int) (*model.Product, error)
+	SetVariantStock(ctx context.Context, variantID uuid.UUID, quantity int) (*model.Variant, error)
+	ReserveStock(ctx context.Context, orderID uuid.UUID, items []service.ReservationItem) error
+	ReleaseStock(ctx context.Context, orderID uuid.UUID) error
+	CommitStock(ctx context.Context, orderID uuid.UUID) error
+	// ReleaseExpiredReservations returns number of orders whose reservations have been released
+	ReleaseExpiredReservations(ctx context.Context) (int, error)
+}
+
+func NewStockService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+	reservationTTL time.Duration,
+) StockService {
+	return &stockService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+		reservationTTL:  reservationTTL,
+	}
+}
+
+type stockService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+	reservationTTL  time.Duration
+}
+
+func (s *stockService) SetStock(ctx context.Context, productID uuid.UUID, quantity int) (*model.Product, error) {
+	var product *model.Product
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		product, err = s.domainService(ctx, provider).SetStock(productID, quantity)
+		return err
+	})
+	return product, err
+}
+
+func (s *stockService) SetVariantStock(ctx context.Context, variantID uuid.UUID, quantity int) (*model.Variant, error) {
+	var variant *model.Variant
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		variant, err = s.domainService(ctx, provider).SetVariantStock(variantID, quantity)
+		return err
+	})
+	return variant, err
+}
+
+func (s *stockService) ReserveStock(ctx context.Context, orderID uuid.UUID, items []service.ReservationItem) error {
+	expiresAt := time.Now().Add(s.reservationTTL)
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).Reserve(orderID, items, expiresAt)
+	})
+	// concurrent call for the same order has reserved stock, this one is rolled back
+	if errors.Is(err, model.ErrReservationExists) {
+		return nil
+	}
+	return err
+}
+
+func (s *stockService) ReleaseStock(ctx context.Context, orderID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).Release(orderID)
+	})
+}
+
+func (s *stockService) CommitStock(ctx context.Context, orderID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).Commit(orderID)
+	})
+}
+
+func (s *stockService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	now := time.Now()
+	var orderIDs []uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		orderIDs, err = provider.ReservationRepository(ctx).FindExpiredOrders(now, expiredReservationsBatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// orders are released in separate transactions to keep product locks short
+	released := 0
+	for _, orderID := range orderIDs {
+		err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+			return s.domainService(ctx, provider).ReleaseExpired(orderID, now)
+		})
+		if err != nil {
+			return released, err
+		}
+		released++
+	}
+	return released, nil
+}
+
+func (s *stockService) domainService(ctx context.Context, provider RepositoryProvider) service.Stock {
+	return service.NewStockService(
+		provider.ProductRepository(ctx),
+		provider.VariantRepository(ctx),
+		provider.ReservationRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"product/pkg/domain/model"
+)
+
+type RepositoryProvider interface {
+	ProductRepository(ctx context.Context) model.ProductRepository
+	VariantRepository(ctx context.Context) model.VariantRepository
+	ReservationRepository(ctx context.Context) model.ReservationRepository
+	CategoryRepository(ctx context.Context) model.CategoryRepository
+	PriceHistoryRepository(ctx context.Context) model.PriceHistoryRepository
+	ScheduledPriceChangeRepository(ctx context.Context) model.ScheduledPriceChangeRepository
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+type VariantService interface {
+	CreateVariant(ctx context.Context, productID uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error)
+	UpdateVariant(ctx context.Context, id uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error)
+	DeleteVariant(ctx context.Context, id uuid.UUID) error
+	GetVariant(ctx context.Context, id uuid.UUID) (*model.Variant, error)
+	ListVariants(ctx context.Context, productID uuid.UUID) ([]*model.Variant, error)
+}
+
+func NewVariantService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) VariantService {
+	return &variantService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type variantService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *variantService) CreateVariant(ctx context.Context, productID uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error) {
+	var variant *model.Variant
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		variant, err = s.domainService(ctx, provider).CreateVariant(productID, sku, attributes, price)
+		return err
+	})
+	return variant, err
+}
+
+func (s *variantService) UpdateVariant(ctx context.Context, id uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error) {
+	var variant *model.Variant
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		variant, err = s.domainService(ctx, provider).UpdateVariant(id, sku, attributes, price)
+		return err
+	})
+	return variant, err
+}
+
+func (s *variantService) DeleteVariant(ctx context.Context, id uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteVariant(id)
+	})
+}
+
+func (s *variantService) GetVariant(ctx context.Context, id uuid.UUID) (*model.Variant, error) {
+	var variant *model.Variant
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		variant, err = s.domainService(ctx, provider).GetVariant(id)
+		return err
+	})
+	return variant, err
+}
+
+func (s *variantService) ListVariants(ctx context.Context, productID uuid.UUID) ([]*model.Variant, error) {
+	var variants []*model.Variant
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		variants, err = s.domainService(ctx, provider).ListVariants(productID)
+		return err
+	})
+	return variants, err
+}
+
+func (s *variantService) domainService(ctx context.Context, provider RepositoryProvider) service.Variant {
+	return service.NewVariantService(
+		provider.ProductRepository(ctx),
+		provider.VariantRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	appservice "product/pkg/application/service"
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+func TestImportSession(t *testing.T) {
+	newService := func() (appservice.ProductService, *mockProductRepository) {
+		repo := &mockProductRepository{store: make(map[string]*model.Product)}
+		return appservice.NewProductService(&mockUnitOfWork{repo: repo}, &mockEventDispatcher{}), repo
+	}
+
+	t.Run("DryRun_ReportsRepeatedNameAsRealRunWould", func(t *testing.T) {
+		products, repo := newService()
+		session := products.NewImportSession()
+
+		product, action, err := session.Import(context.Background(), "Keyboard", 100, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertCreated, action)
+		require.Nil(t, product)
+
+		product, action, err = session.Import(context.Background(), "keyboard", 100, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUnchanged, action)
+		require.Nil(t, product)
+
+		_, action, err = session.Import(context.Background(), "Keyboard", 120, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUpdated, action)
+
+		require.Empty(t, repo.store)
+	})
+
+	t.Run("DryRun_ComparesWithExistingProduct", func(t *testing.T) {
+		products, _ := newService()
+		existing, err := products.CreateProduct(context.Background(), "Mouse", 50)
+		require.NoError(t, err)
+		session := products.NewImportSession()
+
+		product, action, err := session.Import(context.Background(), "Mouse", 60, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUpdated, action)
+		require.Equal(t, existing.ID, product.ID)
+
+		product, action, err = session.Import(context.Background(), "Mouse", 60, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUnchanged, action)
+		require.Equal(t, existing.ID, product.ID)
+
+		stored, err := products.GetProduct(context.Background(), existing.ID)
+		require.NoError(t, err)
+		require.Equal(t, 50.0, stored.Price)
+	})
+
+	t.Run("DryRun_DoesNotRememberFailedRows", func(t *testing.T) {
+		products, _ := newService()
+		session := products.NewImportSession()
+
+		_, _, err := session.Import(context.Background(), "Keyboard", -1, true)
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+
+		_, action, err := session.Import(context.Background(), "Keyboard", 100, true)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertCreated, action)
+	})
+
+	t.Run("RealRun_StoresProducts", func(t *testing.T) {
+		products, repo := newService()
+		session := products.NewImportSession()
+
+		created, action, err := session.Import(context.Background(), "Keyboard", 100, false)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertCreated, action)
+
+		updated, action, err := session.Import(context.Background(), "Keyboard", 120, false)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUpdated, action)
+		require.Equal(t, created.ID, updated.ID)
+		require.Len(t, repo.store, 1)
+	})
+}
+
+// mockUnitOfWork rolls back products stored by failed transaction
+type mockUnitOfWork struct {
+	repo *mockProductRepository
+}
+
+func (m *mockUnitOfWork) Execute(_ context.Context, f func(provider appservice.RepositoryProvider) error) error {
+	snapshot := make(map[string]*model.Product, len(m.repo.store))
+	for name, product := range m.repo.store {
+		productCopy := *product
+		snapshot[name] = &productCopy
+	}
+	err := f(&mockRepositoryProvider{repo: m.repo})
+	if err != nil {
+		m.repo.store = snapshot
+	}
+	return err
+}
+
+type mockRepositoryProvider struct {
+	repo *mockProductRepository
+}
+
+func (m *mockRepositoryProvider) ProductRepository(context.Context) model.ProductRepository {
+	return m.repo
+}
+
+func (m *mockRepositoryProvider) VariantRepository(context.Context) model.VariantRepository {
+	return nil
+}
+
+func (m *mockRepositoryProvider) ReservationRepository(context.Context) model.ReservationRepository {
+	return nil
+}
+
+func (m *mockRepositoryProvider) CategoryRepository(context.Context) model.CategoryRepository {
+	return nil
+}
+
+func (m *mockRepositoryProvider) PriceHistoryRepository(context.Context) model.PriceHistoryRepository {
+	return &mockPriceHistoryRepository{}
+}
+
+func (m *mockRepositoryProvider) ScheduledPriceChangeRepository(context.Context) model.ScheduledPriceChangeRepository {
+	return nil
+}
+
+var _ model.ProductRepository = (*mockProductRepository)(nil)
+
+// mockProductRepository keys products by lowercased name, as MySQL compares names case-insensitively
+type mockProductRepository struct {
+	store map[string]*model.Product
+}
+
+func (m *mockProductRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockProductRepository) Store(product *model.Product) error {
+	maps.DeleteFunc(m.store, func(_ string, existing *model.Product) bool {
+		return existing.ID == product.ID
+	})
+	productCopy := *product
+	m.store[strings.ToLower(product.Name)] = &productCopy
+	return nil
+}
+
+func (m *mockProductRepository) Find(id uuid.UUID) (*model.Product, error) {
+	for _, product := range m.store {
+		if product.ID == id {
+			return product, nil
+		}
+	}
+	return nil, model.ErrProductNotFound
+}
+
+func (m *mockProductRepository) FindForUpdate(id uuid.UUID) (*model.Product, error) {
+	return m.Find(id)
+}
+
+func (m *mockProductRepository) FindByName(name string) (*model.Product, error) {
+	product, ok := m.store[strings.ToLower(name)]
+	if !ok {
+		return nil, model.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (m *mockProductRepository) Delete(id uuid.UUID) error {
+	product, err := m.Find(id)
+	if err != nil {
+		return err
+	}
+	delete(m.store, strings.ToLower(product.Name))
+	return nil
+}
+
+type mockPriceHistoryRepository struct{}
+
+func (m *mockPriceHistoryRepository) Append(model.PriceHistoryEntry) error {
+	return nil
+}
+
+var _ outbox.EventDispatcher[outbox.Event] = (*mockEventDispatcher)(nil)
+
+type mockEventDispatcher struct{}
+
+func (m *mockEventDispatcher) Dispatch(context.Context, outbox.Event) error {
+	return nil
+}
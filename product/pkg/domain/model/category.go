@@ -0,0 +1,35 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrCategoryNameRequired = errors.New("category name is required")
+	ErrCategoryCycle        = errors.New("category can not be moved under itself or its descendant")
+)
+
+type Category struct {
+	ID   uuid.UUID
+	Name string
+	// ParentID is nil for root categories
+	ParentID  *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CategoryRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(category *Category) error
+	Find(id uuid.UUID) (*Category, error)
+	// FindForUpdate locks category until the end of transaction, so concurrent moves can not create a cycle
+	FindForUpdate(id uuid.UUID) (*Category, error)
+	// AssignProduct returns false if product has already been assigned to category
+	AssignProduct(categoryID, productID uuid.UUID) (bool, error)
+	// UnassignProduct returns false if product has not been assigned to category
+	UnassignProduct(categoryID, productID uuid.UUID) (bool, error)
+}
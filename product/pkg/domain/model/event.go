@@ -1,6 +1,10 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ProductCreated struct {
 	ProductID uuid.UUID
@@ -31,3 +35,97 @@ type ProductDeleted struct {
 func (e ProductDeleted) Type() string {
 	return "ProductDeleted"
 }
+
+type StockReserved struct {
+	OrderID   uuid.UUID
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+	Quantity  int
+}
+
+func (e StockReserved) Type() string {
+	return "StockReserved"
+}
+
+type StockReleased struct {
+	OrderID   uuid.UUID
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+	Quantity  int
+}
+
+func (e StockReleased) Type() string {
+	return "StockReleased"
+}
+
+type ProductCategoryAssigned struct {
+	ProductID  uuid.UUID
+	CategoryID uuid.UUID
+}
+
+func (e ProductCategoryAssigned) Type() string {
+	return "ProductCategoryAssigned"
+}
+
+type ProductCategoryUnassigned struct {
+	ProductID  uuid.UUID
+	CategoryID uuid.UUID
+}
+
+func (e ProductCategoryUnassigned) Type() string {
+	return "ProductCategoryUnassigned"
+}
+
+type PriceChangeScheduled struct {
+	ChangeID    uuid.UUID
+	ProductID   uuid.UUID
+	Price       float64
+	EffectiveAt time.Time
+}
+
+func (e PriceChangeScheduled) Type() string {
+	return "PriceChangeScheduled"
+}
+
+type PriceChangeCancelled struct {
+	ChangeID  uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (e PriceChangeCancelled) Type() string {
+	return "PriceChangeCancelled"
+}
+
+type VariantCreated struct {
+	VariantID uuid.UUID
+	ProductID uuid.UUID
+	SKU       string
+	Price     float64
+}
+
+func (e VariantCreated) Type() string {
+	return "VariantCreated"
+}
+
+// VariantUpdated carries effective prices of variant
+type VariantUpdated struct {
+	VariantID uuid.UUID
+	ProductID uuid.UUID
+	OldSKU    string
+	NewSKU    string
+	OldPrice  float64
+	NewPrice  float64
+}
+
+func (e VariantUpdated) Type() string {
+	return "VariantUpdated"
+}
+
+type VariantDeleted struct {
+	VariantID uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (e VariantDeleted) Type() string {
+	return "VariantDeleted"
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPriceChangeNotFound        = errors.New("scheduled price change not found")
+	ErrPriceChangeEffectiveAtPast = errors.New("scheduled price change must take effect in the future")
+)
+
+// PriceHistoryEntry records price product has had since EffectiveFrom until the next entry of the product
+type PriceHistoryEntry struct {
+	ProductID     uuid.UUID
+	Price         float64
+	EffectiveFrom time.Time
+}
+
+type PriceHistoryRepository interface {
+	Append(entry PriceHistoryEntry) error
+}
+
+// ScheduledPriceChange is applied to product once EffectiveAt has come
+type ScheduledPriceChange struct {
+	ID          uuid.UUID
+	ProductID   uuid.UUID
+	Price       float64
+	EffectiveAt time.Time
+	CreatedAt   time.Time
+}
+
+type ScheduledPriceChangeRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(change *ScheduledPriceChange) error
+	Find(id uuid.UUID) (*ScheduledPriceChange, error)
+	// FindForUpdate locks change until the end of transaction, waiting for the change applied by scheduler
+	FindForUpdate(id uuid.UUID) (*ScheduledPriceChange, error)
+	// FindForUpdateSkipLocked locks change until the end of transaction,
+	// change locked by another transaction is reported as ErrPriceChangeNotFound
+	FindForUpdateSkipLocked(id uuid.UUID) (*ScheduledPriceChange, error)
+	// Delete returns ErrPriceChangeNotFound if change has already been removed
+	Delete(id uuid.UUID) error
+	// FindDue returns IDs of changes which take effect not later than the given moment, earliest first.
+	// Changes locked by another transaction are skipped
+	FindDue(at time.Time, limit int) ([]uuid.UUID, error)
+}
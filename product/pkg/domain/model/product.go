@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,23 +12,33 @@ var (
 	ErrProductNotFound     = errors.New("product not found")
 	ErrProductNameExists   = errors.New("product with this name already exists")
 	ErrProductNameRequired = errors.New("product name is required")
-	ErrProductPriceInvalid = errors.New("product price must be zero or positive")
+	ErrProductPriceInvalid = errors.New("product price must be a finite zero or positive number")
+	ErrProductStockInvalid = errors.New("product stock must be zero or positive")
+	ErrInsufficientStock   = errors.New("insufficient product stock")
 )
 
 type Product struct {
-	ID        uuid.UUID
-	Name      string
-	Price     float64
+	ID    uuid.UUID
+	Name  string
+	Price float64
+	// Stock is a quantity available for reservation, reserved units are already subtracted
+	Stock     int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
+// ValidPrice reports whether price may be stored, NaN and infinities are rejected as they can not be persisted
+func ValidPrice(price float64) bool {
+	return !math.IsNaN(price) && !math.IsInf(price, 0) && price >= 0
+}
+
 type ProductRepository interface {
 	NextID() (uuid.UUID, error)
 	Store(product *Product) error
 	Find(id uuid.UUID) (*Product, error)
+	// FindForUpdate locks product until the end of transaction, so its stock can be changed safely
+	FindForUpdate(id uuid.UUID) (*Product, error)
 	FindByName(name string) (*Product, error)
 	Delete(id uuid.UUID) error
-	ListAll() ([]*Product, error)
 }
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReservationNotFound        = errors.New("stock reservation not found")
+	ErrReservationExists          = errors.New("stock reservation already exists")
+	ErrReservationQuantityInvalid = errors.New("reserved quantity must be positive")
+)
+
+type ReservationStatus int
+
+const (
+	ReservationActive ReservationStatus = iota
+	// ReservationCommitted keeps units sold, they are never returned to stock
+	ReservationCommitted
+)
+
+// Reservation holds units of product or its variant for order, it is identified by order, product and variant
+type Reservation struct {
+	OrderID   uuid.UUID
+	ProductID uuid.UUID
+	// VariantID is uuid.Nil when units of product itself are reserved
+	VariantID uuid.UUID
+	Quantity  int
+	Status    ReservationStatus
+	CreatedAt time.Time
+	// ExpiresAt is a moment after which active reservation is released
+	ExpiresAt time.Time
+}
+
+type ReservationRepository interface {
+	// Add fails with ErrReservationExists if the order already reserves the product or variant,
+	// so one of concurrent reservations of the same order is rolled back
+	Add(reservation *Reservation) error
+	// Update changes status of existing reservation
+	Update(reservation *Reservation) error
+	FindByOrder(orderID uuid.UUID) ([]*Reservation, error)
+	Delete(orderID, productID, variantID uuid.UUID) error
+	// FindExpiredOrders returns IDs of orders having active reservations expired before the given moment
+	FindExpiredOrders(before time.Time, limit int) ([]uuid.UUID, error)
+}
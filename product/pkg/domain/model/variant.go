@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVariantNotFound          = errors.New("product variant not found")
+	ErrVariantSKURequired       = errors.New("product variant sku is required")
+	ErrVariantSKUExists         = errors.New("product variant with this sku already exists")
+	ErrVariantAttributesInvalid = errors.New("product variant attributes are invalid")
+	ErrVariantAttributesExist   = errors.New("product variant with these attributes already exists")
+)
+
+type AttributeType int
+
+const (
+	StringAttribute AttributeType = iota
+	NumberAttribute
+	BooleanAttribute
+)
+
+// Attribute keeps value as string, Type tells how it must be parsed
+type Attribute struct {
+	Name  string
+	Type  AttributeType
+	Value string
+}
+
+// Normalize validates attribute and returns it with value in canonical form,
+// so equal numbers and booleans are stored and compared the same way, e.g. "1.0" becomes "1"
+func (a Attribute) Normalize() (Attribute, error) {
+	if a.Name == "" {
+		return Attribute{}, fmt.Errorf("%w: attribute name is required", ErrVariantAttributesInvalid)
+	}
+	var err error
+	switch a.Type {
+	case StringAttribute:
+		if a.Value == "" {
+			err = errors.New("value is required")
+		}
+	case NumberAttribute:
+		var number float64
+		number, err = strconv.ParseFloat(a.Value, 64)
+		// ParseFloat accepts NaN and Inf, which are not comparable attribute values
+		if err == nil && (math.IsNaN(number) || math.IsInf(number, 0)) {
+			err = errors.New("value must be a finite number")
+		}
+		a.Value = strconv.FormatFloat(number, 'f', -1, 64)
+	case BooleanAttribute:
+		var boolean bool
+		boolean, err = strconv.ParseBool(a.Value)
+		a.Value = strconv.FormatBool(boolean)
+	default:
+		err = fmt.Errorf("unknown type %d", a.Type)
+	}
+	if err != nil {
+		return Attribute{}, fmt.Errorf("%w: attribute %q: %v", ErrVariantAttributesInvalid, a.Name, err)
+	}
+	return a, nil
+}
+
+// Variant is a sellable version of product differing from its siblings by attributes only
+type Variant struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	SKU       string
+	// Attributes of all variants of product have the same names and types
+	Attributes []Attribute
+	// Price overrides price of product, nil means price of product is used
+	Price *float64
+	// Stock is a quantity available for reservation, reserved units are already subtracted
+	Stock     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+func (v Variant) EffectivePrice(product Product) float64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return product.Price
+}
+
+// Title is a name of product qualified with attribute values, e.g. "T-shirt (size: M, color: red)"
+func (v Variant) Title(product Product) string {
+	values := make([]string, 0, len(v.Attributes))
+	for _, attribute := range v.Attributes {
+		values = append(values, attribute.Name+": "+attribute.Value)
+	}
+	return product.Name + " (" + strings.Join(values, ", ") + ")"
+}
+
+type VariantRepository interface {
+	NextID() (uuid.UUID, error)
+	// Store fails with ErrVariantSKUExists when another not deleted variant has the same sku
+	Store(variant *Variant) error
+	Find(id uuid.UUID) (*Variant, error)
+	// FindForUpdate locks variant until the end of transaction, so its stock can be changed safely
+	FindForUpdate(id uuid.UUID) (*Variant, error)
+	FindBySKU(sku string) (*Variant, error)
+	// FindByProduct returns not deleted variants of product
+	FindByProduct(productID uuid.UUID) ([]*Variant, error)
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"product/pkg/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Category interface {
+	CreateCategory(name string, parentID *uuid.UUID) (*model.Category, error)
+	// UpdateCategory renames category and moves it under parentID, nil parentID makes category a root one
+	UpdateCategory(id uuid.UUID, name string, parentID *uuid.UUID) (*model.Category, error)
+	// AssignProduct adds product to category, assigning of assigned product does nothing
+	AssignProduct(categoryID, productID uuid.UUID) error
+	// UnassignProduct removes product from category, unassigning of not assigned product does nothing
+	UnassignProduct(categoryID, productID uuid.UUID) error
+}
+
+func NewCategoryService(
+	categoryRepo model.CategoryRepository,
+	productRepo model.ProductRepository,
+	dispatcher EventDispatcher,
+) Category {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+		dispatcher:   dispatcher,
+	}
+}
+
+type categoryService struct {
+	categoryRepo model.CategoryRepository
+	productRepo  model.ProductRepository
+	dispatcher   EventDispatcher
+}
+
+func (s *categoryService) CreateCategory(name string, parentID *uuid.UUID) (*model.Category, error) {
+	if name == "" {
+		return nil, model.ErrCategoryNameRequired
+	}
+	if parentID != nil {
+		if _, err := s.categoryRepo.Find(*parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.categoryRepo.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next category id: %w", err)
+	}
+	now := time.Now()
+	category := &model.Category{
+		ID:        id,
+		Name:      name,
+		ParentID:  parentID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.categoryRepo.Store(category); err != nil {
+		return nil, fmt.Errorf("failed to store category: %w", err)
+	}
+	return category, nil
+}
+
+func (s *categoryService) UpdateCategory(id uuid.UUID, name string, parentID *uuid.UUID) (*model.Category, error) {
+	if name == "" {
+		return nil, model.ErrCategoryNameRequired
+	}
+
+	category, err := s.categoryRepo.FindForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// walking up from the new parent must not reach the category itself,
+	// ancestors are locked so a concurrent move can not change the chain until the update is stored
+	for ancestorID := parentID; ancestorID != nil; {
+		if *ancestorID == id {
+			return nil, model.ErrCategoryCycle
+		}
+		ancestor, err := s.categoryRepo.FindForUpdate(*ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		ancestorID = ancestor.ParentID
+	}
+
+	category.Name = name
+	category.ParentID = parentID
+	category.UpdatedAt = time.Now()
+	if err := s.categoryRepo.Store(category); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	return category, nil
+}
+
+func (s *categoryService) AssignProduct(categoryID, productID uuid.UUID) error {
+	if _, err := s.categoryRepo.Find(categoryID); err != nil {
+		return err
+	}
+	if _, err := s.productRepo.Find(productID); err != nil {
+		return err
+	}
+
+	assigned, err := s.categoryRepo.AssignProduct(categoryID, productID)
+	if err != nil {
+		return fmt.Errorf("failed to assign product to category: %w", err)
+	}
+	if !assigned {
+		return nil
+	}
+	return s.dispatcher.Dispatch(model.ProductCategoryAssigned{
+		ProductID:  productID,
+		CategoryID: categoryID,
+	})
+}
+
+func (s *categoryService) UnassignProduct(categoryID, productID uuid.UUID) error {
+	if _, err := s.categoryRepo.Find(categoryID); err != nil {
+		return err
+	}
+
+	unassigned, err := s.categoryRepo.UnassignProduct(categoryID, productID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign product from category: %w", err)
+	}
+	if !unassigned {
+		return nil
+	}
+	return s.dispatcher.Dispatch(model.ProductCategoryUnassigned{
+		ProductID:  productID,
+		CategoryID: categoryID,
+	})
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"product/pkg/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Price interface {
+	// SchedulePriceChange plans price of product to be changed at effectiveAt, which must be in the future
+	SchedulePriceChange(productID uuid.UUID, price float64, effectiveAt time.Time) (*model.ScheduledPriceChange, error)
+	CancelPriceChange(id uuid.UUID) error
+	// ApplyPriceChange sets price of product if change is due at now and removes the change, it returns true if price has been set.
+	// Applying of applied, cancelled or locked change does nothing, change of deleted product is removed without applying
+	ApplyPriceChange(id uuid.UUID, now time.Time) (bool, error)
+}
+
+func NewPriceService(
+	productRepo model.ProductRepository,
+	priceHistory model.PriceHistoryRepository,
+	changeRepo model.ScheduledPriceChangeRepository,
+	dispatcher EventDispatcher,
+) Price {
+	return &priceService{
+		productRepo: productRepo,
+		changeRepo:  changeRepo,
+		products:    NewProductService(productRepo, priceHistory, dispatcher),
+		dispatcher:  dispatcher,
+	}
+}
+
+type priceService struct {
+	productRepo model.ProductRepository
+	changeRepo  model.ScheduledPriceChangeRepository
+	products    Product
+	dispatcher  EventDispatcher
+}
+
+func (s *priceService) SchedulePriceChange(productID uuid.UUID, price float64, effectiveAt time.Time) (*model.ScheduledPriceChange, error) {
+	if !model.ValidPrice(price) {
+		return nil, model.ErrProductPriceInvalid
+	}
+	now := time.Now()
+	if !effectiveAt.After(now) {
+		return nil, model.ErrPriceChangeEffectiveAtPast
+	}
+	if _, err := s.productRepo.Find(productID); err != nil {
+		return nil, err
+	}
+
+	id, err := s.changeRepo.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next price change id: %w", err)
+	}
+	change := &model.ScheduledPriceChange{
+		ID:          id,
+		ProductID:   productID,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+		CreatedAt:   now,
+	}
+	if err := s.changeRepo.Store(change); err != nil {
+		return nil, fmt.Errorf("failed to store price change: %w", err)
+	}
+
+	event := model.PriceChangeScheduled{
+		ChangeID:    change.ID,
+		ProductID:   change.ProductID,
+		Price:       change.Price,
+		EffectiveAt: change.EffectiveAt,
+	}
+	if err := s.dispatcher.Dispatch(event); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *priceService) CancelPriceChange(id uuid.UUID) error {
+	// lock waits for scheduler applying the change, applied change is not found then
+	change, err := s.changeRepo.FindForUpdate(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.changeRepo.Delete(id); err != nil {
+		if errors.Is(err, model.ErrPriceChangeNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete price change: %w", err)
+	}
+	return s.dispatcher.Dispatch(model.PriceChangeCancelled{
+		ChangeID:  change.ID,
+		ProductID: change.ProductID,
+	})
+}
+
+func (s *priceService) ApplyPriceChange(id uuid.UUID, now time.Time) (bool, error) {
+	// change being applied by another scheduler is skipped, it is removed once applied
+	change, err := s.changeRepo.FindForUpdateSkipLocked(id)
+	if err != nil {
+		if errors.Is(err, model.ErrPriceChangeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if change.EffectiveAt.After(now) {
+		return false, nil
+	}
+
+	applied := false
+	product, err := s.productRepo.Find(change.ProductID)
+	switch {
+	case err == nil:
+		// price is changed the same way as by user, so history is recorded and ProductUpdated is dispatched
+		if _, err := s.products.UpdateProduct(product.ID, product.Name, change.Price); err != nil {
+			return false, err
+		}
+		applied = true
+	case errors.Is(err, model.ErrProductNotFound):
+		// price of deleted product is not changed anymore
+	default:
+		return false, err
+	}
+
+	if err := s.changeRepo.Delete(id); err != nil {
+		return false, fmt.Errorf("failed to delete price change: %w", err)
+	}
+	return applied, nil
+}
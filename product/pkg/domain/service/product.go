@@ -18,31 +18,47 @@ type EventDispatcher interface {
 	Dispatch(event Event) error
 }
 
+type UpsertAction int
+
+const (
+	UpsertCreated UpsertAction = iota
+	UpsertUpdated
+	// UpsertUnchanged means product with the name already has the price
+	UpsertUnchanged
+)
+
 type Product interface {
 	CreateProduct(name string, price float64) (*model.Product, error)
 	UpdateProduct(id uuid.UUID, name string, price float64) (*model.Product, error)
+	// UpsertProduct creates product with the name or changes price of not deleted product having the name
+	UpsertProduct(name string, price float64) (*model.Product, UpsertAction, error)
 	DeleteProduct(id uuid.UUID) error
 	GetProduct(id uuid.UUID) (*model.Product, error)
-	ListAllProducts() ([]*model.Product, error)
 }
 
-func NewProductService(repo model.ProductRepository, dispatcher EventDispatcher) Product {
+func NewProductService(
+	repo model.ProductRepository,
+	priceHistory model.PriceHistoryRepository,
+	dispatcher EventDispatcher,
+) Product {
 	return &productService{
-		repo:       repo,
-		dispatcher: dispatcher,
+		repo:         repo,
+		priceHistory: priceHistory,
+		dispatcher:   dispatcher,
 	}
 }
 
 type productService struct {
-	repo       model.ProductRepository
-	dispatcher EventDispatcher
+	repo         model.ProductRepository
+	priceHistory model.PriceHistoryRepository
+	dispatcher   EventDispatcher
 }
 
 func (s *productService) CreateProduct(name string, price float64) (*model.Product, error) {
 	if name == "" {
 		return nil, model.ErrProductNameRequired
 	}
-	if price < 0 {
+	if !model.ValidPrice(price) {
 		return nil, model.ErrProductPriceInvalid
 	}
 
@@ -69,10 +85,13 @@ func (s *productService) CreateProduct(name string, price float64) (*model.Produ
 	if err := s.repo.Store(product); err != nil {
 		return nil, fmt.Errorf("failed to store product: %w", err)
 	}
+	if err := s.appendPriceHistory(product); err != nil {
+		return nil, err
+	}
 
 	event := model.ProductCreated{ProductID: product.ID, Name: product.Name, Price: product.Price}
 	if err := s.dispatcher.Dispatch(event); err != nil {
-		fmt.Printf("warning: failed to dispatch ProductCreated event: %v\n", err)
+		return nil, err
 	}
 
 	return product, nil
@@ -82,11 +101,12 @@ func (s *productService) UpdateProduct(id uuid.UUID, name string, price float64)
 	if name == "" {
 		return nil, model.ErrProductNameRequired
 	}
-	if price < 0 {
+	if !model.ValidPrice(price) {
 		return nil, model.ErrProductPriceInvalid
 	}
 
-	product, err := s.repo.Find(id)
+	// product is locked so concurrent stock changes are not overwritten
+	product, err := s.repo.FindForUpdate(id)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +125,11 @@ func (s *productService) UpdateProduct(id uuid.UUID, name string, price float64)
 	if err := s.repo.Store(product); err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
+	if product.Price != oldPrice {
+		if err := s.appendPriceHistory(product); err != nil {
+			return nil, err
+		}
+	}
 
 	event := model.ProductUpdated{
 		ProductID: product.ID,
@@ -114,19 +139,36 @@ func (s *productService) UpdateProduct(id uuid.UUID, name string, price float64)
 		NewPrice:  product.Price,
 	}
 	if err := s.dispatcher.Dispatch(event); err != nil {
-		fmt.Printf("warning: failed to dispatch ProductUpdated event: %v\n", err)
+		return nil, err
 	}
 
 	return product, nil
 }
 
+func (s *productService) UpsertProduct(name string, price float64) (*model.Product, UpsertAction, error) {
+	existing, err := s.repo.FindByName(name)
+	switch {
+	case err == nil:
+		if existing.Price == price {
+			return existing, UpsertUnchanged, nil
+		}
+		product, err := s.UpdateProduct(existing.ID, existing.Name, price)
+		return product, UpsertUpdated, err
+	case errors.Is(err, model.ErrProductNotFound):
+		product, err := s.CreateProduct(name, price)
+		return product, UpsertCreated, err
+	default:
+		return nil, UpsertCreated, fmt.Errorf("failed to find product by name: %w", err)
+	}
+}
+
 func (s *productService) DeleteProduct(id uuid.UUID) error {
 	if err := s.repo.Delete(id); err != nil {
 		return err
 	}
 	event := model.ProductDeleted{ProductID: id}
 	if err := s.dispatcher.Dispatch(event); err != nil {
-		fmt.Printf("warning: failed to dispatch ProductDeleted event: %v\n", err)
+		return err
 	}
 	return nil
 }
@@ -135,6 +177,14 @@ func (s *productService) GetProduct(id uuid.UUID) (*model.Product, error) {
 	return s.repo.Find(id)
 }
 
-func (s *productService) ListAllProducts() ([]*model.Product, error) {
-	return s.repo.ListAll()
+func (s *productService) appendPriceHistory(product *model.Product) error {
+	err := s.priceHistory.Append(model.PriceHistoryEntry{
+		ProductID:     product.ID,
+		Price:         product.Price,
+		EffectiveFrom: product.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append price history: %w", err)
+	}
+	return nil
 }
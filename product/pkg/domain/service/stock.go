@@ -0,0 +1,280 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"product/pkg/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// ReservationItem reserves units of product variant, or of product itself when VariantID is uuid.Nil
+type ReservationItem struct {
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+	Quantity  int
+}
+
+type Stock interface {
+	SetStock(productID uuid.UUID, quantity int) (*model.Product, error)
+	SetVariantStock(variantID uuid.UUID, quantity int) (*model.Variant, error)
+	// Reserve holds items for order until expiresAt, either all items are reserved or none.
+	// Reserving for order which already has reservations does nothing
+	Reserve(orderID uuid.UUID, items []ReservationItem, expiresAt time.Time) error
+	// Release returns units of active reservations of order to stock, releasing of released order does nothing
+	Release(orderID uuid.UUID) error
+	// Commit makes active reservations of order permanent, committing of committed order does nothing
+	Commit(orderID uuid.UUID) error
+	// ReleaseExpired releases active reservations of order which expired before now
+	ReleaseExpired(orderID uuid.UUID, now time.Time) error
+}
+
+func NewStockService(
+	productRepo model.ProductRepository,
+	variantRepo model.VariantRepository,
+	reservationRepo model.ReservationRepository,
+	dispatcher EventDispatcher,
+) Stock {
+	return &stockService{
+		productRepo:     productRepo,
+		variantRepo:     variantRepo,
+		reservationRepo: reservationRepo,
+		dispatcher:      dispatcher,
+	}
+}
+
+type stockService struct {
+	productRepo     model.ProductRepository
+	variantRepo     model.VariantRepository
+	reservationRepo model.ReservationRepository
+	dispatcher      EventDispatcher
+}
+
+type stockKey struct {
+	productID uuid.UUID
+	variantID uuid.UUID
+}
+
+// compareStockKeys orders keys by product and variant. Stock rows are always locked in this order,
+// so concurrent reservations of the same products can not deadlock
+func compareStockKeys(a, b stockKey) int {
+	if c := bytes.Compare(a.productID[:], b.productID[:]); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.variantID[:], b.variantID[:])
+}
+
+func reservationStockKey(reservation *model.Reservation) stockKey {
+	return stockKey{productID: reservation.ProductID, variantID: reservation.VariantID}
+}
+
+func (s *stockService) SetStock(productID uuid.UUID, quantity int) (*model.Product, error) {
+	if quantity < 0 {
+		return nil, model.ErrProductStockInvalid
+	}
+
+	product, err := s.productRepo.FindForUpdate(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Stock = quantity
+	product.UpdatedAt = time.Now()
+	if err := s.productRepo.Store(product); err != nil {
+		return nil, fmt.Errorf("failed to update product stock: %w", err)
+	}
+	return product, nil
+}
+
+func (s *stockService) SetVariantStock(variantID uuid.UUID, quantity int) (*model.Variant, error) {
+	if quantity < 0 {
+		return nil, model.ErrProductStockInvalid
+	}
+
+	variant, err := s.variantRepo.FindForUpdate(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant.Stock = quantity
+	variant.UpdatedAt = time.Now()
+	if err := s.variantRepo.Store(variant); err != nil {
+		return nil, fmt.Errorf("failed to update variant stock: %w", err)
+	}
+	return variant, nil
+}
+
+func (s *stockService) Reserve(orderID uuid.UUID, items []ReservationItem, expiresAt time.Time) error {
+	quantities := make(map[stockKey]int, len(items))
+	var keys []stockKey
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return model.ErrReservationQuantityInvalid
+		}
+		key := stockKey{productID: item.ProductID, variantID: item.VariantID}
+		if _, ok := quantities[key]; !ok {
+			keys = append(keys, key)
+		}
+		quantities[key] += item.Quantity
+	}
+
+	reservations, err := s.reservationRepo.FindByOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if len(reservations) > 0 {
+		return nil
+	}
+
+	slices.SortFunc(keys, compareStockKeys)
+	now := time.Now()
+	for _, key := range keys {
+		quantity := quantities[key]
+		if key.variantID != uuid.Nil {
+			// variants of deleted product are not sold
+			if _, err = s.productRepo.Find(key.productID); err != nil {
+				return err
+			}
+		}
+		if err = s.changeStock(key, -quantity, now); err != nil {
+			return err
+		}
+
+		err = s.reservationRepo.Add(&model.Reservation{
+			OrderID:   orderID,
+			ProductID: key.productID,
+			VariantID: key.variantID,
+			Quantity:  quantity,
+			Status:    model.ReservationActive,
+			CreatedAt: now,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add reservation: %w", err)
+		}
+
+		err = s.dispatcher.Dispatch(model.StockReserved{
+			OrderID:   orderID,
+			ProductID: key.productID,
+			VariantID: key.variantID,
+			Quantity:  quantity,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stockService) Release(orderID uuid.UUID) error {
+	return s.release(orderID, func(*model.Reservation) bool {
+		return true
+	})
+}
+
+func (s *stockService) Commit(orderID uuid.UUID) error {
+	reservations, err := s.reservationRepo.FindByOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if len(reservations) == 0 {
+		return model.ErrReservationNotFound
+	}
+
+	slices.SortFunc(reservations, func(a, b *model.Reservation) int {
+		return compareStockKeys(reservationStockKey(a), reservationStockKey(b))
+	})
+	for _, reservation := range reservations {
+		if reservation.Status != model.ReservationActive {
+			continue
+		}
+		reservation.Status = model.ReservationCommitted
+		if err := s.reservationRepo.Update(reservation); err != nil {
+			return fmt.Errorf("failed to commit reservation: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *stockService) ReleaseExpired(orderID uuid.UUID, now time.Time) error {
+	return s.release(orderID, func(reservation *model.Reservation) bool {
+		return reservation.ExpiresAt.Before(now)
+	})
+}
+
+func (s *stockService) release(orderID uuid.UUID, filter func(*model.Reservation) bool) error {
+	reservations, err := s.reservationRepo.FindByOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(reservations, func(a, b *model.Reservation) int {
+		return compareStockKeys(reservationStockKey(a), reservationStockKey(b))
+	})
+	for _, reservation := range reservations {
+		if reservation.Status != model.ReservationActive || !filter(reservation) {
+			continue
+		}
+
+		key := reservationStockKey(reservation)
+		err = s.changeStock(key, reservation.Quantity, time.Now())
+		// stock of deleted product or variant is not tracked anymore
+		if err != nil && !errors.Is(err, model.ErrProductNotFound) && !errors.Is(err, model.ErrVariantNotFound) {
+			return err
+		}
+
+		if err := s.reservationRepo.Delete(orderID, reservation.ProductID, reservation.VariantID); err != nil {
+			return fmt.Errorf("failed to delete reservation: %w", err)
+		}
+		err = s.dispatcher.Dispatch(model.StockReleased{
+			OrderID:   orderID,
+			ProductID: reservation.ProductID,
+			VariantID: reservation.VariantID,
+			Quantity:  reservation.Quantity,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeStock adds delta to stock of product or its variant, stock can not become negative
+func (s *stockService) changeStock(key stockKey, delta int, now time.Time) error {
+	if key.variantID == uuid.Nil {
+		product, err := s.productRepo.FindForUpdate(key.productID)
+		if err != nil {
+			return err
+		}
+		if product.Stock+delta < 0 {
+			return fmt.Errorf("%w: product %s has %d, requested %d", model.ErrInsufficientStock, key.productID, product.Stock, -delta)
+		}
+		product.Stock += delta
+		product.UpdatedAt = now
+		if err := s.productRepo.Store(product); err != nil {
+			return fmt.Errorf("failed to update product stock: %w", err)
+		}
+		return nil
+	}
+
+	variant, err := s.variantRepo.FindForUpdate(key.variantID)
+	if err != nil {
+		return err
+	}
+	if variant.ProductID != key.productID {
+		return model.ErrVariantNotFound
+	}
+	if variant.Stock+delta < 0 {
+		return fmt.Errorf("%w: variant %s has %d, requested %d", model.ErrInsufficientStock, key.variantID, variant.Stock, -delta)
+	}
+	variant.Stock += delta
+	variant.UpdatedAt = now
+	if err := s.variantRepo.Store(variant); err != nil {
+		return fmt.Errorf("failed to update variant stock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"product/pkg/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Variant interface {
+	// CreateVariant adds variant to product, nil price means variant is sold by price of product
+	CreateVariant(productID uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error)
+	UpdateVariant(id uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error)
+	DeleteVariant(id uuid.UUID) error
+	// GetVariant fails with ErrVariantNotFound for variants of deleted products too
+	GetVariant(id uuid.UUID) (*model.Variant, error)
+	ListVariants(productID uuid.UUID) ([]*model.Variant, error)
+}
+
+func NewVariantService(
+	productRepo model.ProductRepository,
+	variantRepo model.VariantRepository,
+	dispatcher EventDispatcher,
+) Variant {
+	return &variantService{
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+type variantService struct {
+	productRepo model.ProductRepository
+	variantRepo model.VariantRepository
+	dispatcher  EventDispatcher
+}
+
+func (s *variantService) CreateVariant(productID uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error) {
+	product, err := s.productRepo.Find(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.variantRepo.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next variant id: %w", err)
+	}
+	now := time.Now()
+	variant := &model.Variant{
+		ID:         id,
+		ProductID:  productID,
+		SKU:        sku,
+		Attributes: attributes,
+		Price:      price,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.validate(variant); err != nil {
+		return nil, err
+	}
+
+	if err := s.variantRepo.Store(variant); err != nil {
+		return nil, fmt.Errorf("failed to store variant: %w", err)
+	}
+
+	event := model.VariantCreated{
+		VariantID: variant.ID,
+		ProductID: variant.ProductID,
+		SKU:       variant.SKU,
+		Price:     variant.EffectivePrice(*product),
+	}
+	if err := s.dispatcher.Dispatch(event); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (s *variantService) UpdateVariant(id uuid.UUID, sku string, attributes []model.Attribute, price *float64) (*model.Variant, error) {
+	// variant is locked so concurrent stock changes are not overwritten
+	variant, err := s.variantRepo.FindForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.findProduct(variant.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSKU := variant.SKU
+	oldPrice := variant.EffectivePrice(*product)
+
+	variant.SKU = sku
+	variant.Attributes = attributes
+	variant.Price = price
+	if err := s.validate(variant); err != nil {
+		return nil, err
+	}
+	variant.UpdatedAt = time.Now()
+
+	if err := s.variantRepo.Store(variant); err != nil {
+		return nil, fmt.Errorf("failed to update variant: %w", err)
+	}
+
+	event := model.VariantUpdated{
+		VariantID: variant.ID,
+		ProductID: variant.ProductID,
+		OldSKU:    oldSKU,
+		NewSKU:    variant.SKU,
+		OldPrice:  oldPrice,
+		NewPrice:  variant.EffectivePrice(*product),
+	}
+	if err := s.dispatcher.Dispatch(event); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (s *variantService) DeleteVariant(id uuid.UUID) error {
+	variant, err := s.variantRepo.Find(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.variantRepo.Delete(id); err != nil {
+		return err
+	}
+	return s.dispatcher.Dispatch(model.VariantDeleted{
+		VariantID: variant.ID,
+		ProductID: variant.ProductID,
+	})
+}
+
+func (s *variantService) GetVariant(id uuid.UUID) (*model.Variant, error) {
+	variant, err := s.variantRepo.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.findProduct(variant.ProductID); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (s *variantService) ListVariants(productID uuid.UUID) ([]*model.Variant, error) {
+	if _, err := s.productRepo.Find(productID); err != nil {
+		return nil, err
+	}
+	return s.variantRepo.FindByProduct(productID)
+}
+
+// findProduct reports variant of deleted product as not found
+func (s *variantService) findProduct(productID uuid.UUID) (*model.Product, error) {
+	product, err := s.productRepo.Find(productID)
+	if errors.Is(err, model.ErrProductNotFound) {
+		return nil, model.ErrVariantNotFound
+	}
+	return product, err
+}
+
+// validate checks variant against its siblings, which must have the same attribute names and types
+// and differ from it by attribute values
+func (s *variantService) validate(variant *model.Variant) error {
+	if variant.SKU == "" {
+		return model.ErrVariantSKURequired
+	}
+	if variant.Price != nil && !model.ValidPrice(*variant.Price) {
+		return model.ErrProductPriceInvalid
+	}
+	if len(variant.Attributes) == 0 {
+		return fmt.Errorf("%w: at least one attribute is required", model.ErrVariantAttributesInvalid)
+	}
+	types := make(map[string]model.AttributeType, len(variant.Attributes))
+	values := make(map[string]string, len(variant.Attributes))
+	attributes := make([]model.Attribute, 0, len(variant.Attributes))
+	for _, raw := range variant.Attributes {
+		attribute, err := raw.Normalize()
+		if err != nil {
+			return err
+		}
+		attributes = append(attributes, attribute)
+		if _, ok := types[attribute.Name]; ok {
+			return fmt.Errorf("%w: attribute %q is duplicated", model.ErrVariantAttributesInvalid, attribute.Name)
+		}
+		types[attribute.Name] = attribute.Type
+		values[attribute.Name] = attribute.Value
+	}
+	variant.Attributes = attributes
+
+	existing, err := s.variantRepo.FindBySKU(variant.SKU)
+	switch {
+	case err == nil:
+		if existing.ID != variant.ID {
+			return model.ErrVariantSKUExists
+		}
+	case !errors.Is(err, model.ErrVariantNotFound):
+		return fmt.Errorf("failed to check variant sku existence: %w", err)
+	}
+
+	siblings, err := s.variantRepo.FindByProduct(variant.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to find product variants: %w", err)
+	}
+	for _, sibling := range siblings {
+		if sibling.ID == variant.ID {
+			continue
+		}
+		if len(sibling.Attributes) != len(types) {
+			return fmt.Errorf("%w: attributes differ from other variants of product", model.ErrVariantAttributesInvalid)
+		}
+		sameValues := true
+		for _, attribute := range sibling.Attributes {
+			attributeType, ok := types[attribute.Name]
+			if !ok || attributeType != attribute.Type {
+				return fmt.Errorf("%w: attributes differ from other variants of product", model.ErrVariantAttributesInvalid)
+			}
+			// values stored before normalization was introduced are compared in canonical form too
+			if normalized, err := attribute.Normalize(); err == nil {
+				attribute = normalized
+			}
+			sameValues = sameValues && values[attribute.Name] == attribute.Value
+		}
+		if sameValues {
+			return model.ErrVariantAttributesExist
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"testing"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryService(t *testing.T) {
+	newServicesWithRepo := func() (service.Product, service.Category, *mockEventDispatcher, *mockCategoryRepository) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		categories := &mockCategoryRepository{
+			store:    make(map[uuid.UUID]*model.Category),
+			products: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		}
+		dispatcher := &mockEventDispatcher{}
+		return service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher), service.NewCategoryService(categories, repo, dispatcher), dispatcher, categories
+	}
+	newServices := func() (service.Product, service.Category, *mockEventDispatcher) {
+		products, categories, dispatcher, _ := newServicesWithRepo()
+		return products, categories, dispatcher
+	}
+
+	t.Run("CreateCategory_WithParent", func(t *testing.T) {
+		_, categories, _ := newServices()
+
+		root, err := categories.CreateCategory("Electronics", nil)
+		require.NoError(t, err)
+		require.Nil(t, root.ParentID)
+
+		child, err := categories.CreateCategory("Keyboards", &root.ID)
+		require.NoError(t, err)
+		require.Equal(t, root.ID, *child.ParentID)
+
+		_, err = categories.CreateCategory("", nil)
+		require.ErrorIs(t, err, model.ErrCategoryNameRequired)
+
+		unknownID := uuid.New()
+		_, err = categories.CreateCategory("Mice", &unknownID)
+		require.ErrorIs(t, err, model.ErrCategoryNotFound)
+	})
+
+	t.Run("UpdateCategory_FailsOnCycle", func(t *testing.T) {
+		_, categories, _ := newServices()
+
+		root, err := categories.CreateCategory("Electronics", nil)
+		require.NoError(t, err)
+		child, err := categories.CreateCategory("Peripherals", &root.ID)
+		require.NoError(t, err)
+		grandchild, err := categories.CreateCategory("Keyboards", &child.ID)
+		require.NoError(t, err)
+
+		_, err = categories.UpdateCategory(root.ID, root.Name, &grandchild.ID)
+		require.ErrorIs(t, err, model.ErrCategoryCycle)
+		_, err = categories.UpdateCategory(root.ID, root.Name, &root.ID)
+		require.ErrorIs(t, err, model.ErrCategoryCycle)
+
+		// moving to root and renaming is allowed
+		updated, err := categories.UpdateCategory(grandchild.ID, "Mechanical keyboards", nil)
+		require.NoError(t, err)
+		require.Nil(t, updated.ParentID)
+		require.Equal(t, "Mechanical keyboards", updated.Name)
+	})
+
+	t.Run("UpdateCategory_LocksAncestorsWhenMovingUnderDescendant", func(t *testing.T) {
+		_, categories, _, repo := newServicesWithRepo()
+
+		root, err := categories.CreateCategory("Electronics", nil)
+		require.NoError(t, err)
+		child, err := categories.CreateCategory("Peripherals", &root.ID)
+		require.NoError(t, err)
+		grandchild, err := categories.CreateCategory("Keyboards", &child.ID)
+		require.NoError(t, err)
+		other, err := categories.CreateCategory("Office", nil)
+		require.NoError(t, err)
+
+		_, err = categories.UpdateCategory(child.ID, child.Name, &grandchild.ID)
+		require.ErrorIs(t, err, model.ErrCategoryCycle)
+		require.Equal(t, []uuid.UUID{child.ID, grandchild.ID}, repo.locked)
+		require.Equal(t, root.ID, *child.ParentID)
+
+		repo.locked = nil
+		_, err = categories.UpdateCategory(grandchild.ID, grandchild.Name, &other.ID)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{grandchild.ID, other.ID}, repo.locked)
+	})
+
+	t.Run("AssignProduct_DispatchesEventsOnlyOnChange", func(t *testing.T) {
+		products, categories, dispatcher := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		category, err := categories.CreateCategory("Keyboards", nil)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		require.NoError(t, categories.AssignProduct(category.ID, product.ID))
+		require.NoError(t, categories.AssignProduct(category.ID, product.ID))
+		require.Len(t, dispatcher.events, 1)
+		assigned, ok := dispatcher.events[0].(model.ProductCategoryAssigned)
+		require.True(t, ok)
+		require.Equal(t, product.ID, assigned.ProductID)
+		require.Equal(t, category.ID, assigned.CategoryID)
+
+		require.NoError(t, categories.UnassignProduct(category.ID, product.ID))
+		require.NoError(t, categories.UnassignProduct(category.ID, product.ID))
+		require.Len(t, dispatcher.events, 2)
+		_, ok = dispatcher.events[1].(model.ProductCategoryUnassigned)
+		require.True(t, ok)
+	})
+
+	t.Run("AssignProduct_FailsForUnknownProductOrCategory", func(t *testing.T) {
+		products, categories, _ := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		category, err := categories.CreateCategory("Keyboards", nil)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, categories.AssignProduct(category.ID, uuid.New()), model.ErrProductNotFound)
+		require.ErrorIs(t, categories.AssignProduct(uuid.New(), product.ID), model.ErrCategoryNotFound)
+	})
+}
+
+var _ model.CategoryRepository = (*mockCategoryRepository)(nil)
+
+type mockCategoryRepository struct {
+	store    map[uuid.UUID]*model.Category
+	products map[uuid.UUID]map[uuid.UUID]struct{}
+	locked   []uuid.UUID
+}
+
+func (m *mockCategoryRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockCategoryRepository) Store(category *model.Category) error {
+	m.store[category.ID] = category
+	return nil
+}
+
+func (m *mockCategoryRepository) Find(id uuid.UUID) (*model.Category, error) {
+	category, ok := m.store[id]
+	if !ok {
+		return nil, model.ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) FindForUpdate(id uuid.UUID) (*model.Category, error) {
+	category, err := m.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	m.locked = append(m.locked, id)
+	return category, nil
+}
+
+func (m *mockCategoryRepository) AssignProduct(categoryID, productID uuid.UUID) (bool, error) {
+	if _, ok := m.products[categoryID][productID]; ok {
+		return false, nil
+	}
+	if m.products[categoryID] == nil {
+		m.products[categoryID] = make(map[uuid.UUID]struct{})
+	}
+	m.products[categoryID][productID] = struct{}{}
+	return true, nil
+}
+
+func (m *mockCategoryRepository) UnassignProduct(categoryID, productID uuid.UUID) (bool, error) {
+	if _, ok := m.products[categoryID][productID]; !ok {
+		return false, nil
+	}
+	delete(m.products[categoryID], productID)
+	return true, nil
+}
@@ -0,0 +1,236 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceService(t *testing.T) {
+	newServices := func() (service.Product, service.Price, *mockPriceHistoryRepository, *mockScheduledPriceChangeRepository, *mockEventDispatcher) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		history := &mockPriceHistoryRepository{}
+		changes := &mockScheduledPriceChangeRepository{store: make(map[uuid.UUID]*model.ScheduledPriceChange)}
+		dispatcher := &mockEventDispatcher{}
+		return service.NewProductService(repo, history, dispatcher),
+			service.NewPriceService(repo, history, changes, dispatcher),
+			history, changes, dispatcher
+	}
+
+	t.Run("UpdateProduct_AppendsHistoryOnlyOnPriceChange", func(t *testing.T) {
+		products, _, history, _, _ := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = products.UpdateProduct(product.ID, "Mechanical keyboard", 100)
+		require.NoError(t, err)
+		_, err = products.UpdateProduct(product.ID, "Mechanical keyboard", 120)
+		require.NoError(t, err)
+
+		require.Len(t, history.entries, 2)
+		require.Equal(t, 100.0, history.entries[0].Price)
+		require.Equal(t, 120.0, history.entries[1].Price)
+		require.Equal(t, product.ID, history.entries[1].ProductID)
+	})
+
+	t.Run("ApplyPriceChange_AppliesOnlyDueChange", func(t *testing.T) {
+		products, prices, history, changes, dispatcher := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		effectiveAt := time.Now().Add(time.Hour)
+		change, err := prices.SchedulePriceChange(product.ID, 80, effectiveAt)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		applied, err := prices.ApplyPriceChange(change.ID, effectiveAt.Add(-time.Minute))
+		require.NoError(t, err)
+		require.False(t, applied)
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 100.0, stored.Price)
+		require.Empty(t, dispatcher.events)
+
+		applied, err = prices.ApplyPriceChange(change.ID, effectiveAt)
+		require.NoError(t, err)
+		require.True(t, applied)
+		stored, err = products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 80.0, stored.Price)
+		require.Len(t, history.entries, 2)
+		require.Empty(t, changes.store)
+
+		require.Len(t, dispatcher.events, 1)
+		event, ok := dispatcher.events[0].(model.ProductUpdated)
+		require.True(t, ok)
+		require.Equal(t, 100.0, event.OldPrice)
+		require.Equal(t, 80.0, event.NewPrice)
+
+		// applied change is removed, so applying it again does nothing
+		applied, err = prices.ApplyPriceChange(change.ID, effectiveAt)
+		require.NoError(t, err)
+		require.False(t, applied)
+		require.Len(t, dispatcher.events, 1)
+	})
+
+	t.Run("ApplyPriceChange_RemovesChangeOfDeletedProduct", func(t *testing.T) {
+		products, prices, _, changes, _ := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		effectiveAt := time.Now().Add(time.Hour)
+		change, err := prices.SchedulePriceChange(product.ID, 80, effectiveAt)
+		require.NoError(t, err)
+		require.NoError(t, products.DeleteProduct(product.ID))
+
+		applied, err := prices.ApplyPriceChange(change.ID, effectiveAt)
+		require.NoError(t, err)
+		require.False(t, applied)
+		require.Empty(t, changes.store)
+	})
+
+	t.Run("ApplyPriceChange_SkipsChangeLockedByAnotherScheduler", func(t *testing.T) {
+		products, prices, _, changes, dispatcher := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		effectiveAt := time.Now().Add(time.Hour)
+		change, err := prices.SchedulePriceChange(product.ID, 80, effectiveAt)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		changes.lockedElsewhere = map[uuid.UUID]struct{}{change.ID: {}}
+		applied, err := prices.ApplyPriceChange(change.ID, effectiveAt)
+		require.NoError(t, err)
+		require.False(t, applied)
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 100.0, stored.Price)
+		require.Empty(t, dispatcher.events)
+		require.Contains(t, changes.store, change.ID)
+	})
+
+	t.Run("SchedulePriceChange_FailsOnInvalidInput", func(t *testing.T) {
+		products, prices, _, _, _ := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+
+		_, err = prices.SchedulePriceChange(product.ID, 80, time.Now().Add(-time.Minute))
+		require.ErrorIs(t, err, model.ErrPriceChangeEffectiveAtPast)
+		_, err = prices.SchedulePriceChange(product.ID, -1, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+		_, err = prices.SchedulePriceChange(uuid.New(), 80, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrProductNotFound)
+	})
+
+	t.Run("CancelPriceChange_RemovesChange", func(t *testing.T) {
+		products, prices, _, changes, dispatcher := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		change, err := prices.SchedulePriceChange(product.ID, 80, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		require.NoError(t, prices.CancelPriceChange(change.ID))
+		require.Empty(t, changes.store)
+		require.Len(t, dispatcher.events, 1)
+		_, ok := dispatcher.events[0].(model.PriceChangeCancelled)
+		require.True(t, ok)
+
+		require.ErrorIs(t, prices.CancelPriceChange(change.ID), model.ErrPriceChangeNotFound)
+	})
+
+	t.Run("CancelPriceChange_FailsForAppliedChange", func(t *testing.T) {
+		products, prices, _, _, dispatcher := newServices()
+
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		effectiveAt := time.Now().Add(time.Hour)
+		change, err := prices.SchedulePriceChange(product.ID, 80, effectiveAt)
+		require.NoError(t, err)
+		_, err = prices.ApplyPriceChange(change.ID, effectiveAt)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		require.ErrorIs(t, prices.CancelPriceChange(change.ID), model.ErrPriceChangeNotFound)
+		require.Empty(t, dispatcher.events)
+	})
+}
+
+var _ model.PriceHistoryRepository = (*mockPriceHistoryRepository)(nil)
+
+type mockPriceHistoryRepository struct {
+	entries []model.PriceHistoryEntry
+}
+
+func (m *mockPriceHistoryRepository) Append(entry model.PriceHistoryEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+var _ model.ScheduledPriceChangeRepository = (*mockScheduledPriceChangeRepository)(nil)
+
+type mockScheduledPriceChangeRepository struct {
+	store map[uuid.UUID]*model.ScheduledPriceChange
+	// lockedElsewhere holds changes locked by another transaction
+	lockedElsewhere map[uuid.UUID]struct{}
+}
+
+func (m *mockScheduledPriceChangeRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockScheduledPriceChangeRepository) Store(change *model.ScheduledPriceChange) error {
+	m.store[change.ID] = change
+	return nil
+}
+
+func (m *mockScheduledPriceChangeRepository) Find(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	change, ok := m.store[id]
+	if !ok {
+		return nil, model.ErrPriceChangeNotFound
+	}
+	return change, nil
+}
+
+func (m *mockScheduledPriceChangeRepository) FindForUpdateSkipLocked(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	if _, ok := m.lockedElsewhere[id]; ok {
+		return nil, model.ErrPriceChangeNotFound
+	}
+	return m.Find(id)
+}
+
+func (m *mockScheduledPriceChangeRepository) FindForUpdate(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	return m.Find(id)
+}
+
+func (m *mockScheduledPriceChangeRepository) Delete(id uuid.UUID) error {
+	if _, ok := m.store[id]; !ok {
+		return model.ErrPriceChangeNotFound
+	}
+	delete(m.store, id)
+	return nil
+}
+
+func (m *mockScheduledPriceChangeRepository) FindDue(at time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, change := range m.store {
+		if !change.EffectiveAt.After(at) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
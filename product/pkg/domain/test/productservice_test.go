@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -18,7 +20,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		name, price := "Test Laptop", 1200.50
 		product, err := svc.CreateProduct(name, price)
@@ -36,13 +38,26 @@ func TestProductService(t *testing.T) {
 		require.Equal(t, product.ID, event.ProductID)
 	})
 
+	t.Run("CreateProduct_FailsOnDispatchError", func(t *testing.T) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		dispatchErr := errors.New("dispatch failed")
+		dispatcher := &mockEventDispatcher{err: dispatchErr}
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
+
+		_, err := svc.CreateProduct("Test Laptop", 1200.50)
+		require.ErrorIs(t, err, dispatchErr)
+	})
+
 	t.Run("CreateProduct_FailsOnEmptyName", func(t *testing.T) {
 		repo := &mockProductRepository{
 			storeByID:   make(map[uuid.UUID]*model.Product),
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		_, err := svc.CreateProduct("", 100)
 		require.ErrorIs(t, err, model.ErrProductNameRequired)
@@ -54,10 +69,14 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		_, err := svc.CreateProduct("Mouse", -10)
 		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+		_, err = svc.CreateProduct("Mouse", math.NaN())
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+		_, err = svc.CreateProduct("Mouse", math.Inf(1))
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
 	})
 
 	t.Run("CreateProduct_FailsOnDuplicateName", func(t *testing.T) {
@@ -66,7 +85,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		_, _ = svc.CreateProduct("Duplicate Product", 100)
 		_, err := svc.CreateProduct("Duplicate Product", 200)
@@ -79,7 +98,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		product, err := svc.CreateProduct("Product 1", 10.0)
 		require.NoError(t, err)
@@ -110,7 +129,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		_, err := svc.UpdateProduct(uuid.New(), "any name", 10)
 		require.ErrorIs(t, err, model.ErrProductNotFound)
@@ -123,7 +142,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		product, err := svc.CreateProduct("Valid", 50)
 		require.NoError(t, err)
@@ -140,7 +159,7 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		p1, err := svc.CreateProduct("Product A", 10)
 		require.NoError(t, err)
@@ -153,13 +172,59 @@ func TestProductService(t *testing.T) {
 		require.Empty(t, dispatcher.events)
 	})
 
+	t.Run("UpsertProduct_CreatesOrUpdatesByName", func(t *testing.T) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
+
+		created, action, err := svc.UpsertProduct("Keyboard", 100)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertCreated, action)
+
+		updated, action, err := svc.UpsertProduct("Keyboard", 120)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUpdated, action)
+		require.Equal(t, created.ID, updated.ID)
+		require.Equal(t, 120.0, updated.Price)
+		dispatcher.Clear()
+
+		_, action, err = svc.UpsertProduct("Keyboard", 120)
+		require.NoError(t, err)
+		require.Equal(t, service.UpsertUnchanged, action)
+		require.Empty(t, dispatcher.events)
+	})
+
+	t.Run("UpsertProduct_FailsOnInvalidRow", func(t *testing.T) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		dispatcher := &mockEventDispatcher{}
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
+
+		_, _, err := svc.UpsertProduct("", 100)
+		require.ErrorIs(t, err, model.ErrProductNameRequired)
+		_, _, err = svc.UpsertProduct("Keyboard", -1)
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+
+		_, err = svc.CreateProduct("Mouse", 10)
+		require.NoError(t, err)
+		_, _, err = svc.UpsertProduct("Mouse", -1)
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+		_, _, err = svc.UpsertProduct("Mouse", math.NaN())
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+	})
+
 	t.Run("DeleteProduct_SuccessfullyDeletesAProduct", func(t *testing.T) {
 		repo := &mockProductRepository{
 			storeByID:   make(map[uuid.UUID]*model.Product),
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		product, err := svc.CreateProduct("To Be Deleted", 50)
 		require.NoError(t, err)
@@ -183,40 +248,13 @@ func TestProductService(t *testing.T) {
 			storeByName: make(map[string]*model.Product),
 		}
 		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
+		svc := service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher)
 
 		err := svc.DeleteProduct(uuid.New())
 		require.ErrorIs(t, err, model.ErrProductNotFound)
 		require.Empty(t, dispatcher.events)
 	})
 
-	t.Run("ListAllProducts_ReturnsOnlyActiveProducts", func(t *testing.T) {
-		repo := &mockProductRepository{
-			storeByID:   make(map[uuid.UUID]*model.Product),
-			storeByName: make(map[string]*model.Product),
-		}
-		dispatcher := &mockEventDispatcher{}
-		svc := service.NewProductService(repo, dispatcher)
-
-		p1, err := svc.CreateProduct("Product 1", 10)
-		require.NoError(t, err)
-		p2, err := svc.CreateProduct("Product 2", 20)
-		require.NoError(t, err)
-		p3, err := svc.CreateProduct("Product 3 (deleted)", 30)
-		require.NoError(t, err)
-		err = svc.DeleteProduct(p3.ID)
-		require.NoError(t, err)
-
-		products, err := svc.ListAllProducts()
-		require.NoError(t, err)
-		require.Len(t, products, 2)
-
-		var foundIDs []uuid.UUID
-		for _, p := range products {
-			foundIDs = append(foundIDs, p.ID)
-		}
-		require.ElementsMatch(t, []uuid.UUID{p1.ID, p2.ID}, foundIDs)
-	})
 }
 
 var _ model.ProductRepository = (*mockProductRepository)(nil)
@@ -258,6 +296,10 @@ func (m *mockProductRepository) Find(id uuid.UUID) (*model.Product, error) {
 	return p, nil
 }
 
+func (m *mockProductRepository) FindForUpdate(id uuid.UUID) (*model.Product, error) {
+	return m.Find(id)
+}
+
 func (m *mockProductRepository) FindByName(name string) (*model.Product, error) {
 	p, ok := m.storeByName[name]
 	if !ok || p.DeletedAt != nil {
@@ -278,23 +320,17 @@ func (m *mockProductRepository) Delete(id uuid.UUID) error {
 	return m.Store(p)
 }
 
-func (m *mockProductRepository) ListAll() ([]*model.Product, error) {
-	var products []*model.Product
-	for _, p := range m.storeByID {
-		if p.DeletedAt == nil {
-			products = append(products, p)
-		}
-	}
-	return products, nil
-}
-
 var _ service.EventDispatcher = (*mockEventDispatcher)(nil)
 
 type mockEventDispatcher struct {
 	events []service.Event
+	err    error
 }
 
 func (m *mockEventDispatcher) Dispatch(e service.Event) error {
+	if m.err != nil {
+		return m.err
+	}
 	m.events = append(m.events, e)
 	return nil
 }
@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockService(t *testing.T) {
+	newServices := func() (service.Product, service.Stock, *mockReservationRepository, *mockEventDispatcher) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		reservations := &mockReservationRepository{store: make(map[uuid.UUID][]*model.Reservation)}
+		dispatcher := &mockEventDispatcher{}
+		variants := &mockVariantRepository{store: make(map[uuid.UUID]*model.Variant)}
+		return service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher),
+			service.NewStockService(repo, variants, reservations, dispatcher),
+			reservations, dispatcher
+	}
+
+	t.Run("Reserve_SubtractsStockAndDispatchesEvent", func(t *testing.T) {
+		products, stock, reservations, dispatcher := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 5)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		orderID := uuid.New()
+		items := []service.ReservationItem{{ProductID: product.ID, Quantity: 2}, {ProductID: product.ID, Quantity: 1}}
+		err = stock.Reserve(orderID, items, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, stored.Stock)
+		require.Len(t, reservations.store[orderID], 1)
+		require.Equal(t, 3, reservations.store[orderID][0].Quantity)
+
+		require.Len(t, dispatcher.events, 1)
+		event, ok := dispatcher.events[0].(model.StockReserved)
+		require.True(t, ok)
+		require.Equal(t, orderID, event.OrderID)
+		require.Equal(t, 3, event.Quantity)
+
+		// repeated reservation for the same order does nothing
+		err = stock.Reserve(orderID, items, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		stored, err = products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, stored.Stock)
+	})
+
+	t.Run("Reserve_FailsWhenOverlappingCallHasReserved", func(t *testing.T) {
+		products, stock, reservations, _ := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 5)
+		require.NoError(t, err)
+
+		orderID := uuid.New()
+		items := []service.ReservationItem{{ProductID: product.ID, Quantity: 2}}
+		require.NoError(t, stock.Reserve(orderID, items, time.Now().Add(time.Hour)))
+
+		// overlapping call has not seen reservation made by the first one
+		reservations.hideExisting = true
+		err = stock.Reserve(orderID, items, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrReservationExists)
+	})
+
+	t.Run("Reserve_FailsOnInsufficientStock", func(t *testing.T) {
+		products, stock, _, dispatcher := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 1)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		err = stock.Reserve(uuid.New(), []service.ReservationItem{{ProductID: product.ID, Quantity: 2}}, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrInsufficientStock)
+		require.Empty(t, dispatcher.events)
+	})
+
+	t.Run("Reserve_LocksProductsInIDOrder", func(t *testing.T) {
+		products, stock, _, dispatcher := newServices()
+		var productIDs []uuid.UUID
+		for _, name := range []string{"Keyboard", "Mouse", "Monitor"} {
+			product, err := products.CreateProduct(name, 100)
+			require.NoError(t, err)
+			_, err = stock.SetStock(product.ID, 5)
+			require.NoError(t, err)
+			productIDs = append(productIDs, product.ID)
+		}
+		slices.SortFunc(productIDs, func(a, b uuid.UUID) int {
+			return bytes.Compare(a[:], b[:])
+		})
+		dispatcher.Clear()
+
+		orderID := uuid.New()
+		items := []service.ReservationItem{
+			{ProductID: productIDs[2], Quantity: 1},
+			{ProductID: productIDs[0], Quantity: 1},
+			{ProductID: productIDs[1], Quantity: 1},
+		}
+		require.NoError(t, stock.Reserve(orderID, items, time.Now().Add(time.Hour)))
+		require.NoError(t, stock.Release(orderID))
+
+		var reserved, released []uuid.UUID
+		for _, event := range dispatcher.events {
+			switch e := event.(type) {
+			case model.StockReserved:
+				reserved = append(reserved, e.ProductID)
+			case model.StockReleased:
+				released = append(released, e.ProductID)
+			}
+		}
+		require.Equal(t, productIDs, reserved)
+		require.Equal(t, productIDs, released)
+	})
+
+	t.Run("Release_ReturnsActiveReservationsToStock", func(t *testing.T) {
+		products, stock, reservations, dispatcher := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 5)
+		require.NoError(t, err)
+
+		orderID := uuid.New()
+		err = stock.Reserve(orderID, []service.ReservationItem{{ProductID: product.ID, Quantity: 3}}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		require.NoError(t, stock.Release(orderID))
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 5, stored.Stock)
+		require.Empty(t, reservations.store[orderID])
+
+		require.Len(t, dispatcher.events, 1)
+		event, ok := dispatcher.events[0].(model.StockReleased)
+		require.True(t, ok)
+		require.Equal(t, 3, event.Quantity)
+
+		// repeated release does nothing
+		require.NoError(t, stock.Release(orderID))
+		require.Len(t, dispatcher.events, 1)
+	})
+
+	t.Run("Commit_KeepsStockSubtracted", func(t *testing.T) {
+		products, stock, _, _ := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 5)
+		require.NoError(t, err)
+
+		orderID := uuid.New()
+		err = stock.Reserve(orderID, []service.ReservationItem{{ProductID: product.ID, Quantity: 3}}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, stock.Commit(orderID))
+		require.NoError(t, stock.Commit(orderID))
+		require.NoError(t, stock.Release(orderID))
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, stored.Stock)
+
+		require.ErrorIs(t, stock.Commit(uuid.New()), model.ErrReservationNotFound)
+	})
+
+	t.Run("ReleaseExpired_ReleasesOnlyExpiredReservations", func(t *testing.T) {
+		products, stock, _, _ := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+		_, err = stock.SetStock(product.ID, 5)
+		require.NoError(t, err)
+
+		now := time.Now()
+		expiredOrderID, activeOrderID := uuid.New(), uuid.New()
+		err = stock.Reserve(expiredOrderID, []service.ReservationItem{{ProductID: product.ID, Quantity: 1}}, now.Add(-time.Minute))
+		require.NoError(t, err)
+		err = stock.Reserve(activeOrderID, []service.ReservationItem{{ProductID: product.ID, Quantity: 2}}, now.Add(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, stock.ReleaseExpired(expiredOrderID, now))
+		require.NoError(t, stock.ReleaseExpired(activeOrderID, now))
+
+		stored, err := products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3, stored.Stock)
+	})
+
+	t.Run("SetStock_FailsOnNegativeQuantity", func(t *testing.T) {
+		products, stock, _, _ := newServices()
+		product, err := products.CreateProduct("Keyboard", 100)
+		require.NoError(t, err)
+
+		_, err = stock.SetStock(product.ID, -1)
+		require.ErrorIs(t, err, model.ErrProductStockInvalid)
+	})
+}
+
+var _ model.ReservationRepository = (*mockReservationRepository)(nil)
+
+type mockReservationRepository struct {
+	store map[uuid.UUID][]*model.Reservation
+	// hideExisting makes FindByOrder miss stored reservations as a read of concurrent transaction does
+	hideExisting bool
+}
+
+func (m *mockReservationRepository) Add(reservation *model.Reservation) error {
+	for _, r := range m.store[reservation.OrderID] {
+		if r.ProductID == reservation.ProductID && r.VariantID == reservation.VariantID {
+			return model.ErrReservationExists
+		}
+	}
+	m.store[reservation.OrderID] = append(m.store[reservation.OrderID], reservation)
+	return nil
+}
+
+func (m *mockReservationRepository) Update(reservation *model.Reservation) error {
+	for i, r := range m.store[reservation.OrderID] {
+		if r.ProductID == reservation.ProductID && r.VariantID == reservation.VariantID {
+			m.store[reservation.OrderID][i] = reservation
+			return nil
+		}
+	}
+	return model.ErrReservationNotFound
+}
+
+func (m *mockReservationRepository) FindByOrder(orderID uuid.UUID) ([]*model.Reservation, error) {
+	if m.hideExisting {
+		return nil, nil
+	}
+	return m.store[orderID], nil
+}
+
+func (m *mockReservationRepository) Delete(orderID, productID, variantID uuid.UUID) error {
+	var rest []*model.Reservation
+	for _, r := range m.store[orderID] {
+		if r.ProductID != productID || r.VariantID != variantID {
+			rest = append(rest, r)
+		}
+	}
+	m.store[orderID] = rest
+	return nil
+}
+
+func (m *mockReservationRepository) FindExpiredOrders(before time.Time, limit int) ([]uuid.UUID, error) {
+	var orderIDs []uuid.UUID
+	for orderID, reservations := range m.store {
+		for _, r := range reservations {
+			if r.Status == model.ReservationActive && r.ExpiresAt.Before(before) {
+				orderIDs = append(orderIDs, orderID)
+				break
+			}
+		}
+	}
+	if len(orderIDs) > limit {
+		orderIDs = orderIDs[:limit]
+	}
+	return orderIDs, nil
+}
@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariantService(t *testing.T) {
+	type services struct {
+		products service.Product
+		variants service.Variant
+		stock    service.Stock
+	}
+	newServices := func() (services, *mockEventDispatcher) {
+		repo := &mockProductRepository{
+			storeByID:   make(map[uuid.UUID]*model.Product),
+			storeByName: make(map[string]*model.Product),
+		}
+		variants := &mockVariantRepository{store: make(map[uuid.UUID]*model.Variant)}
+		reservations := &mockReservationRepository{store: make(map[uuid.UUID][]*model.Reservation)}
+		dispatcher := &mockEventDispatcher{}
+		return services{
+			products: service.NewProductService(repo, &mockPriceHistoryRepository{}, dispatcher),
+			variants: service.NewVariantService(repo, variants, dispatcher),
+			stock:    service.NewStockService(repo, variants, reservations, dispatcher),
+		}, dispatcher
+	}
+	sizeAndColor := func(size, color string) []model.Attribute {
+		return []model.Attribute{
+			{Name: "size", Type: model.StringAttribute, Value: size},
+			{Name: "color", Type: model.StringAttribute, Value: color},
+		}
+	}
+
+	t.Run("CreateVariant_InheritsOrOverridesProductPrice", func(t *testing.T) {
+		s, dispatcher := newServices()
+		product, err := s.products.CreateProduct("T-shirt", 20)
+		require.NoError(t, err)
+		dispatcher.Clear()
+
+		inherited, err := s.variants.CreateVariant(product.ID, "TS-M-RED", sizeAndColor("M", "red"), nil)
+		require.NoError(t, err)
+		require.Equal(t, 20.0, inherited.EffectivePrice(*product))
+		require.Equal(t, "T-shirt (size: M, color: red)", inherited.Title(*product))
+
+		price := 25.0
+		overridden, err := s.variants.CreateVariant(product.ID, "TS-XL-RED", sizeAndColor("XL", "red"), &price)
+		require.NoError(t, err)
+		require.Equal(t, 25.0, overridden.EffectivePrice(*product))
+
+		require.Len(t, dispatcher.events, 2)
+		event, ok := dispatcher.events[1].(model.VariantCreated)
+		require.True(t, ok)
+		require.Equal(t, product.ID, event.ProductID)
+		require.Equal(t, 25.0, event.Price)
+
+		variants, err := s.variants.ListVariants(product.ID)
+		require.NoError(t, err)
+		require.Len(t, variants, 2)
+	})
+
+	t.Run("CreateVariant_ComparesNumberAttributesByValue", func(t *testing.T) {
+		s, _ := newServices()
+		product, err := s.products.CreateProduct("Shoes", 50)
+		require.NoError(t, err)
+		size := func(value string) []model.Attribute {
+			return []model.Attribute{{Name: "size", Type: model.NumberAttribute, Value: value}}
+		}
+
+		variant, err := s.variants.CreateVariant(product.ID, "SH-42", size("42.0"), nil)
+		require.NoError(t, err)
+		require.Equal(t, "42", variant.Attributes[0].Value)
+
+		_, err = s.variants.CreateVariant(product.ID, "SH-42-2", size("42"), nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesExist)
+		_, err = s.variants.CreateVariant(product.ID, "SH-42-3", size("4.2e1"), nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesExist)
+
+		for _, value := range []string{"NaN", "Inf", "-Inf"} {
+			_, err = s.variants.CreateVariant(product.ID, "SH-"+value, size(value), nil)
+			require.ErrorIs(t, err, model.ErrVariantAttributesInvalid)
+		}
+	})
+
+	t.Run("CreateVariant_FailsOnInvalidVariant", func(t *testing.T) {
+		s, _ := newServices()
+		product, err := s.products.CreateProduct("T-shirt", 20)
+		require.NoError(t, err)
+		_, err = s.variants.CreateVariant(product.ID, "TS-M-RED", sizeAndColor("M", "red"), nil)
+		require.NoError(t, err)
+
+		_, err = s.variants.CreateVariant(product.ID, "", sizeAndColor("L", "red"), nil)
+		require.ErrorIs(t, err, model.ErrVariantSKURequired)
+		_, err = s.variants.CreateVariant(product.ID, "TS-M-RED", sizeAndColor("L", "red"), nil)
+		require.ErrorIs(t, err, model.ErrVariantSKUExists)
+		_, err = s.variants.CreateVariant(product.ID, "TS-M-RED-2", sizeAndColor("M", "red"), nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesExist)
+
+		// siblings must share attribute names and types
+		_, err = s.variants.CreateVariant(product.ID, "TS-L", []model.Attribute{{Name: "size", Type: model.StringAttribute, Value: "L"}}, nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesInvalid)
+		_, err = s.variants.CreateVariant(product.ID, "TS-42-RED", []model.Attribute{
+			{Name: "size", Type: model.NumberAttribute, Value: "42"},
+			{Name: "color", Type: model.StringAttribute, Value: "red"},
+		}, nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesInvalid)
+
+		other, err := s.products.CreateProduct("Shoes", 50)
+		require.NoError(t, err)
+		_, err = s.variants.CreateVariant(other.ID, "SH-X", []model.Attribute{{Name: "size", Type: model.NumberAttribute, Value: "X"}}, nil)
+		require.ErrorIs(t, err, model.ErrVariantAttributesInvalid)
+
+		price := -1.0
+		_, err = s.variants.CreateVariant(product.ID, "TS-L-RED", sizeAndColor("L", "red"), &price)
+		require.ErrorIs(t, err, model.ErrProductPriceInvalid)
+		_, err = s.variants.CreateVariant(uuid.New(), "TS-L-RED", sizeAndColor("L", "red"), nil)
+		require.ErrorIs(t, err, model.ErrProductNotFound)
+	})
+
+	t.Run("GetVariant_FailsForDeletedProduct", func(t *testing.T) {
+		s, _ := newServices()
+		product, err := s.products.CreateProduct("T-shirt", 20)
+		require.NoError(t, err)
+		variant, err := s.variants.CreateVariant(product.ID, "TS-M-RED", sizeAndColor("M", "red"), nil)
+		require.NoError(t, err)
+
+		require.NoError(t, s.products.DeleteProduct(product.ID))
+		_, err = s.variants.GetVariant(variant.ID)
+		require.ErrorIs(t, err, model.ErrVariantNotFound)
+	})
+
+	t.Run("Reserve_TakesStockOfVariant", func(t *testing.T) {
+		s, _ := newServices()
+		product, err := s.products.CreateProduct("T-shirt", 20)
+		require.NoError(t, err)
+		_, err = s.stock.SetStock(product.ID, 10)
+		require.NoError(t, err)
+		variant, err := s.variants.CreateVariant(product.ID, "TS-M-RED", sizeAndColor("M", "red"), nil)
+		require.NoError(t, err)
+		_, err = s.stock.SetVariantStock(variant.ID, 3)
+		require.NoError(t, err)
+
+		orderID := uuid.New()
+		items := []service.ReservationItem{{ProductID: product.ID, VariantID: variant.ID, Quantity: 4}}
+		err = s.stock.Reserve(orderID, items, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrInsufficientStock)
+
+		items[0].Quantity = 2
+		require.NoError(t, s.stock.Reserve(orderID, items, time.Now().Add(time.Hour)))
+		stored, err := s.variants.GetVariant(variant.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, stored.Stock)
+		storedProduct, err := s.products.GetProduct(product.ID)
+		require.NoError(t, err)
+		require.Equal(t, 10, storedProduct.Stock)
+
+		require.NoError(t, s.stock.Release(orderID))
+		stored, err = s.variants.GetVariant(variant.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3, stored.Stock)
+
+		// variant must belong to reserved product
+		err = s.stock.Reserve(uuid.New(), []service.ReservationItem{{ProductID: uuid.New(), VariantID: variant.ID, Quantity: 1}}, time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, model.ErrProductNotFound)
+	})
+}
+
+var _ model.VariantRepository = (*mockVariantRepository)(nil)
+
+type mockVariantRepository struct {
+	store map[uuid.UUID]*model.Variant
+}
+
+func (m *mockVariantRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockVariantRepository) Store(variant *model.Variant) error {
+	m.store[variant.ID] = variant
+	return nil
+}
+
+func (m *mockVariantRepository) Find(id uuid.UUID) (*model.Variant, error) {
+	variant, ok := m.store[id]
+	if !ok || variant.DeletedAt != nil {
+		return nil, model.ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+func (m *mockVariantRepository) FindForUpdate(id uuid.UUID) (*model.Variant, error) {
+	return m.Find(id)
+}
+
+func (m *mockVariantRepository) FindBySKU(sku string) (*model.Variant, error) {
+	for _, variant := range m.store {
+		if variant.SKU == sku && variant.DeletedAt == nil {
+			return variant, nil
+		}
+	}
+	return nil, model.ErrVariantNotFound
+}
+
+func (m *mockVariantRepository) FindByProduct(productID uuid.UUID) ([]*model.Variant, error) {
+	var variants []*model.Variant
+	for _, variant := range m.store {
+		if variant.ProductID == productID && variant.DeletedAt == nil {
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
+
+func (m *mockVariantRepository) Delete(id uuid.UUID) error {
+	variant, ok := m.store[id]
+	if !ok || variant.DeletedAt != nil {
+		return model.ErrVariantNotFound
+	}
+	now := time.Now()
+	variant.DeletedAt = &now
+	return nil
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/application/query"
+	"product/pkg/domain/model"
+)
+
+func NewCategoryQueryService(client mysql.ClientContext) query.CategoryQueryService {
+	return &categoryQueryService{
+		client: client,
+	}
+}
+
+type categoryQueryService struct {
+	client mysql.ClientContext
+}
+
+func (c *categoryQueryService) ListCategories(ctx context.Context) ([]*model.Category, error) {
+	var categories []struct {
+		CategoryID uuid.UUID           `db:"category_id"`
+		Name       string              `db:"name"`
+		ParentID   sql.Null[uuid.UUID] `db:"parent_id"`
+		CreatedAt  time.Time           `db:"created_at"`
+		UpdatedAt  time.Time           `db:"updated_at"`
+	}
+	// depth ordering puts parents before their children
+	err := c.client.SelectContext(
+		ctx,
+		&categories,
+		`
+	WITH RECURSIVE tree (category_id, depth) AS (
+		SELECT category_id, 0 FROM category WHERE parent_id IS NULL
+		UNION ALL
+		SELECT c.category_id, t.depth + 1 FROM category c INNER JOIN tree t ON c.parent_id = t.category_id
+	)
+	SELECT c.category_id, c.name, c.parent_id, c.created_at, c.updated_at FROM category c
+	INNER JOIN tree t ON t.category_id = c.category_id
+	ORDER BY t.depth, c.name, c.category_id
+	`,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*model.Category, 0, len(categories))
+	for _, category := range categories {
+		c := &model.Category{
+			ID:        category.CategoryID,
+			Name:      category.Name,
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.UpdatedAt,
+		}
+		if category.ParentID.Valid {
+			c.ParentID = &category.ParentID.V
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/application/query"
+	"product/pkg/domain/model"
+)
+
+func NewPriceQueryService(client mysql.ClientContext) query.PriceQueryService {
+	return &priceQueryService{
+		client: client,
+	}
+}
+
+type priceQueryService struct {
+	client mysql.ClientContext
+}
+
+func (p *priceQueryService) GetPriceAt(ctx context.Context, productID uuid.UUID, at time.Time) (*model.PriceHistoryEntry, error) {
+	var entry struct {
+		ProductID     uuid.UUID `db:"product_id"`
+		Price         float64   `db:"price"`
+		EffectiveFrom time.Time `db:"effective_from"`
+	}
+	// entries with the same effective_from are ordered by insertion
+	err := p.client.GetContext(
+		ctx,
+		&entry,
+		`
+	SELECT product_id, price, effective_from FROM price_history
+	WHERE product_id = ? AND effective_from <= ?
+	ORDER BY effective_from DESC, price_history_id DESC
+	LIMIT 1
+	`,
+		productID,
+		at,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(query.ErrPriceNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &model.PriceHistoryEntry{
+		ProductID:     entry.ProductID,
+		Price:         entry.Price,
+		EffectiveFrom: entry.EffectiveFrom,
+	}, nil
+}
+
+func (p *priceQueryService) ListScheduledPriceChanges(ctx context.Context, productID uuid.UUID) ([]*model.ScheduledPriceChange, error) {
+	var changes []struct {
+		PriceChangeID uuid.UUID `db:"price_change_id"`
+		ProductID     uuid.UUID `db:"product_id"`
+		Price         float64   `db:"price"`
+		EffectiveAt   time.Time `db:"effective_at"`
+		CreatedAt     time.Time `db:"created_at"`
+	}
+	err := p.client.SelectContext(
+		ctx,
+		&changes,
+		`
+	SELECT price_change_id, product_id, price, effective_at, created_at FROM scheduled_price_change
+	WHERE product_id = ?
+	ORDER BY effective_at, price_change_id
+	`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*model.ScheduledPriceChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, &model.ScheduledPriceChange{
+			ID:          change.PriceChangeID,
+			ProductID:   change.ProductID,
+			Price:       change.Price,
+			EffectiveAt: change.EffectiveAt,
+			CreatedAt:   change.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,209 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/application/query"
+	"product/pkg/domain/model"
+)
+
+func NewProductQueryService(client mysql.ClientContext) query.ProductQueryService {
+	return &productQueryService{
+		client: client,
+	}
+}
+
+type productQueryService struct {
+	client mysql.ClientContext
+}
+
+var sortColumns = map[query.ProductSortField]string{
+	query.SortByName:      "name",
+	query.SortByPrice:     "price",
+	query.SortByCreatedAt: "created_at",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (p *productQueryService) ListProducts(ctx context.Context, spec query.ListProductsSpec) (query.ProductPage, error) {
+	sortColumn, ok := sortColumns[spec.SortBy]
+	if !ok {
+		return query.ProductPage{}, errors.Errorf("unknown sort field %d", spec.SortBy)
+	}
+	pageSize := spec.PageSize
+	if pageSize <= 0 {
+		pageSize = query.DefaultPageSize
+	}
+	pageSize = min(pageSize, query.MaxPageSize)
+
+	var conditions []string
+	var args []interface{}
+	if !spec.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if spec.NamePrefix != "" {
+		conditions = append(conditions, "name LIKE ?")
+		args = append(args, likeEscaper.Replace(spec.NamePrefix)+"%")
+	}
+	if spec.NameContains != "" {
+		conditions = append(conditions, "name LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(spec.NameContains)+"%")
+	}
+	if spec.CategoryID != nil {
+		conditions = append(conditions, `product_id IN (
+			WITH RECURSIVE subcategory (category_id) AS (
+				SELECT ?
+				UNION ALL
+				SELECT c.category_id FROM category c INNER JOIN subcategory s ON c.parent_id = s.category_id
+			)
+			SELECT pc.product_id FROM product_category pc INNER JOIN subcategory s ON pc.category_id = s.category_id
+		)`)
+		args = append(args, *spec.CategoryID)
+	}
+	if spec.MinPrice != nil {
+		conditions = append(conditions, "price >= ?")
+		args = append(args, *spec.MinPrice)
+	}
+	if spec.MaxPrice != nil {
+		conditions = append(conditions, "price <= ?")
+		args = append(args, *spec.MaxPrice)
+	}
+
+	direction, comparison := "ASC", ">"
+	if spec.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if spec.Cursor != "" {
+		c, err := decodeCursor(spec.Cursor)
+		if err != nil {
+			return query.ProductPage{}, err
+		}
+		if c.SortBy != spec.SortBy || c.Descending != spec.Descending {
+			return query.ProductPage{}, errors.WithStack(query.ErrInvalidCursor)
+		}
+		// keyset pagination, product_id breaks ties of equal sort values
+		value := c.sortValue()
+		conditions = append(conditions, "("+sortColumn+" "+comparison+" ? OR ("+sortColumn+" = ? AND product_id "+comparison+" ?))")
+		args = append(args, value, value, c.ProductID)
+	}
+
+	var where string
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var products []sqlxProduct
+	err := p.client.SelectContext(
+		ctx,
+		&products,
+		`SELECT product_id, name, price, stock, created_at, updated_at, deleted_at FROM product
+		`+where+`
+		ORDER BY `+sortColumn+` `+direction+`, product_id `+direction+`
+		LIMIT ?`,
+		append(args, pageSize+1)...,
+	)
+	if err != nil {
+		return query.ProductPage{}, errors.WithStack(err)
+	}
+
+	var nextCursor string
+	if len(products) > pageSize {
+		products = products[:pageSize]
+		last := products[len(products)-1]
+		nextCursor, err = encodeCursor(cursor{
+			SortBy:     spec.SortBy,
+			Descending: spec.Descending,
+			Name:       last.Name,
+			Price:      last.Price,
+			CreatedAt:  last.CreatedAt,
+			ProductID:  last.ProductID,
+		})
+		if err != nil {
+			return query.ProductPage{}, err
+		}
+	}
+
+	result := make([]*model.Product, 0, len(products))
+	for _, product := range products {
+		result = append(result, product.toModel())
+	}
+	return query.ProductPage{
+		Products:   result,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// cursor points to the last product of the page, only the field used for sorting is meaningful
+type cursor struct {
+	SortBy     query.ProductSortField `json:"s"`
+	Descending bool                   `json:"d"`
+	Name       string                 `json:"n,omitempty"`
+	Price      float64                `json:"p,omitempty"`
+	CreatedAt  time.Time              `json:"c,omitempty"`
+	ProductID  uuid.UUID              `json:"id"`
+}
+
+func (c cursor) sortValue() interface{} {
+	switch c.SortBy {
+	case query.SortByPrice:
+		return c.Price
+	case query.SortByCreatedAt:
+		return c.CreatedAt
+	default:
+		return c.Name
+	}
+}
+
+func encodeCursor(c cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, errors.WithStack(query.ErrInvalidCursor)
+	}
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return cursor{}, errors.WithStack(query.ErrInvalidCursor)
+	}
+	return c, nil
+}
+
+type sqlxProduct struct {
+	ProductID uuid.UUID           `db:"product_id"`
+	Name      string              `db:"name"`
+	Price     float64             `db:"price"`
+	Stock     int                 `db:"stock"`
+	CreatedAt time.Time           `db:"created_at"`
+	UpdatedAt time.Time           `db:"updated_at"`
+	DeletedAt sql.Null[time.Time] `db:"deleted_at"`
+}
+
+func (p sqlxProduct) toModel() *model.Product {
+	product := &model.Product{
+		ID:        p.ProductID,
+		Name:      p.Name,
+		Price:     p.Price,
+		Stock:     p.Stock,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+	if p.DeletedAt.Valid {
+		product.DeletedAt = &p.DeletedAt.V
+	}
+	return product
+}
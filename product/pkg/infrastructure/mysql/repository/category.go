@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+func NewCategoryRepository(ctx context.Context, client mysql.ClientContext) model.CategoryRepository {
+	return &categoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type categoryRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (c *categoryRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (c *categoryRepository) Store(category *model.Category) error {
+	_, err := c.client.ExecContext(c.ctx,
+		`
+	INSERT INTO category (category_id, name, parent_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		name = VALUES(name),
+		parent_id = VALUES(parent_id),
+		updated_at = VALUES(updated_at)
+	`,
+		category.ID,
+		category.Name,
+		toSQLNull(category.ParentID),
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (c *categoryRepository) Find(id uuid.UUID) (*model.Category, error) {
+	return c.findOne(`SELECT category_id, name, parent_id, created_at, updated_at FROM category WHERE category_id = ?`, id)
+}
+
+func (c *categoryRepository) FindForUpdate(id uuid.UUID) (*model.Category, error) {
+	return c.findOne(`SELECT category_id, name, parent_id, created_at, updated_at FROM category WHERE category_id = ? FOR UPDATE`, id)
+}
+
+func (c *categoryRepository) findOne(query string, id uuid.UUID) (*model.Category, error) {
+	var category sqlxCategory
+	err := c.client.GetContext(c.ctx, &category, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrCategoryNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &model.Category{
+		ID:        category.CategoryID,
+		Name:      category.Name,
+		ParentID:  fromSQLNull(category.ParentID),
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}, nil
+}
+
+func (c *categoryRepository) AssignProduct(categoryID, productID uuid.UUID) (bool, error) {
+	res, err := c.client.ExecContext(c.ctx,
+		`INSERT IGNORE INTO product_category (product_id, category_id) VALUES (?, ?)`,
+		productID,
+		categoryID,
+	)
+	return rowsAffected(res, err)
+}
+
+func (c *categoryRepository) UnassignProduct(categoryID, productID uuid.UUID) (bool, error) {
+	res, err := c.client.ExecContext(c.ctx,
+		`DELETE FROM product_category WHERE product_id = ? AND category_id = ?`,
+		productID,
+		categoryID,
+	)
+	return rowsAffected(res, err)
+}
+
+func rowsAffected(res sql.Result, err error) (bool, error) {
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return affected > 0, nil
+}
+
+type sqlxCategory struct {
+	CategoryID uuid.UUID           `db:"category_id"`
+	Name       string              `db:"name"`
+	ParentID   sql.Null[uuid.UUID] `db:"parent_id"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+}
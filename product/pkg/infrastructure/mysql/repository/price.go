@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+func NewPriceHistoryRepository(ctx context.Context, client mysql.ClientContext) model.PriceHistoryRepository {
+	return &priceHistoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type priceHistoryRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *priceHistoryRepository) Append(entry model.PriceHistoryEntry) error {
+	_, err := p.client.ExecContext(p.ctx,
+		`INSERT INTO price_history (product_id, price, effective_from) VALUES (?, ?, ?)`,
+		entry.ProductID,
+		entry.Price,
+		entry.EffectiveFrom,
+	)
+	return errors.WithStack(err)
+}
+
+func NewScheduledPriceChangeRepository(ctx context.Context, client mysql.ClientContext) model.ScheduledPriceChangeRepository {
+	return &scheduledPriceChangeRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type scheduledPriceChangeRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (s *scheduledPriceChangeRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (s *scheduledPriceChangeRepository) Store(change *model.ScheduledPriceChange) error {
+	_, err := s.client.ExecContext(s.ctx,
+		`
+	INSERT INTO scheduled_price_change (price_change_id, product_id, price, effective_at, created_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		price = VALUES(price),
+		effective_at = VALUES(effective_at)
+	`,
+		change.ID,
+		change.ProductID,
+		change.Price,
+		change.EffectiveAt,
+		change.CreatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (s *scheduledPriceChangeRepository) Find(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	return s.findOne(`SELECT price_change_id, product_id, price, effective_at, created_at FROM scheduled_price_change WHERE price_change_id = ?`, id)
+}
+
+func (s *scheduledPriceChangeRepository) FindForUpdate(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	return s.findOne(`SELECT price_change_id, product_id, price, effective_at, created_at FROM scheduled_price_change WHERE price_change_id = ? FOR UPDATE`, id)
+}
+
+func (s *scheduledPriceChangeRepository) FindForUpdateSkipLocked(id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	return s.findOne(`SELECT price_change_id, product_id, price, effective_at, created_at FROM scheduled_price_change WHERE price_change_id = ? FOR UPDATE SKIP LOCKED`, id)
+}
+
+func (s *scheduledPriceChangeRepository) findOne(query string, id uuid.UUID) (*model.ScheduledPriceChange, error) {
+	var change sqlxScheduledPriceChange
+	err := s.client.GetContext(s.ctx, &change, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrPriceChangeNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return change.toModel(), nil
+}
+
+func (s *scheduledPriceChangeRepository) Delete(id uuid.UUID) error {
+	res, err := s.client.ExecContext(s.ctx,
+		`DELETE FROM scheduled_price_change WHERE price_change_id = ?`,
+		id,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrPriceChangeNotFound)
+	}
+	return nil
+}
+
+func (s *scheduledPriceChangeRepository) FindDue(at time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := s.client.SelectContext(
+		s.ctx,
+		&ids,
+		`SELECT price_change_id FROM scheduled_price_change WHERE effective_at <= ? ORDER BY effective_at, price_change_id LIMIT ? FOR UPDATE SKIP LOCKED`,
+		at,
+		limit,
+	)
+	return ids, errors.WithStack(err)
+}
+
+type sqlxScheduledPriceChange struct {
+	PriceChangeID uuid.UUID `db:"price_change_id"`
+	ProductID     uuid.UUID `db:"product_id"`
+	Price         float64   `db:"price"`
+	EffectiveAt   time.Time `db:"effective_at"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+func (s sqlxScheduledPriceChange) toModel() *model.ScheduledPriceChange {
+	return &model.ScheduledPriceChange{
+		ID:          s.PriceChangeID,
+		ProductID:   s.ProductID,
+		Price:       s.Price,
+		EffectiveAt: s.EffectiveAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
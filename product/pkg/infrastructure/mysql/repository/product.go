@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+// duplicateEntryErrorCode is returned by MySQL on unique index violation
+const duplicateEntryErrorCode = 1062
+
+func NewProductRepository(ctx context.Context, client mysql.ClientContext) model.ProductRepository {
+	return &productRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type productRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *productRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+// Store fails with ErrProductNameExists when another not deleted product has the same name
+func (p *productRepository) Store(product *model.Product) error {
+	var exists bool
+	err := p.client.GetContext(p.ctx, &exists, `SELECT EXISTS(SELECT 1 FROM product WHERE product_id = ?)`, product.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// INSERT ... ON DUPLICATE KEY UPDATE is not used since it would update product owning the same name
+	if !exists {
+		_, err = p.client.ExecContext(p.ctx,
+			`INSERT INTO product (product_id, name, price, stock, created_at, updated_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			product.ID,
+			product.Name,
+			product.Price,
+			product.Stock,
+			product.CreatedAt,
+			product.UpdatedAt,
+			toSQLNull(product.DeletedAt),
+		)
+	} else {
+		_, err = p.client.ExecContext(p.ctx,
+			`UPDATE product SET name = ?, price = ?, stock = ?, updated_at = ?, deleted_at = ? WHERE product_id = ?`,
+			product.Name,
+			product.Price,
+			product.Stock,
+			product.UpdatedAt,
+			toSQLNull(product.DeletedAt),
+			product.ID,
+		)
+	}
+	return errors.WithStack(productError(err))
+}
+
+func (p *productRepository) Find(id uuid.UUID) (*model.Product, error) {
+	var product sqlxProduct
+	err := p.client.GetContext(
+		p.ctx,
+		&product,
+		`SELECT product_id, name, price, stock, created_at, updated_at, deleted_at FROM product WHERE product_id = ? AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrProductNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return product.toModel(), nil
+}
+
+func (p *productRepository) FindForUpdate(id uuid.UUID) (*model.Product, error) {
+	var product sqlxProduct
+	err := p.client.GetContext(
+		p.ctx,
+		&product,
+		`SELECT product_id, name, price, stock, created_at, updated_at, deleted_at FROM product WHERE product_id = ? AND deleted_at IS NULL FOR UPDATE`,
+		id,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrProductNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return product.toModel(), nil
+}
+
+func (p *productRepository) FindByName(name string) (*model.Product, error) {
+	var product sqlxProduct
+	err := p.client.GetContext(
+		p.ctx,
+		&product,
+		`SELECT product_id, name, price, stock, created_at, updated_at, deleted_at FROM product WHERE active_name = ?`,
+		name,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrProductNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return product.toModel(), nil
+}
+
+func (p *productRepository) Delete(id uuid.UUID) error {
+	currentTime := time.Now()
+	res, err := p.client.ExecContext(p.ctx,
+		`UPDATE product SET deleted_at = ?, updated_at = ? WHERE product_id = ? AND deleted_at IS NULL`,
+		currentTime,
+		currentTime,
+		id,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrProductNotFound)
+	}
+	return nil
+}
+
+func productError(err error) error {
+	var mysqlErr *gomysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntryErrorCode {
+		return model.ErrProductNameExists
+	}
+	return err
+}
+
+type sqlxProduct struct {
+	ProductID uuid.UUID           `db:"product_id"`
+	Name      string              `db:"name"`
+	Price     float64             `db:"price"`
+	Stock     int                 `db:"stock"`
+	CreatedAt time.Time           `db:"created_at"`
+	UpdatedAt time.Time           `db:"updated_at"`
+	DeletedAt sql.Null[time.Time] `db:"deleted_at"`
+}
+
+func (p sqlxProduct) toModel() *model.Product {
+	return &model.Product{
+		ID:        p.ProductID,
+		Name:      p.Name,
+		Price:     p.Price,
+		Stock:     p.Stock,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+		DeletedAt: fromSQLNull(p.DeletedAt),
+	}
+}
+
+func fromSQLNull[T any](v sql.Null[T]) *T {
+	if v.Valid {
+		return &v.V
+	}
+	return nil
+}
+
+func toSQLNull[T any](v *T) sql.Null[T] {
+	if v == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{
+		V:     *v,
+		Valid: true,
+	}
+}
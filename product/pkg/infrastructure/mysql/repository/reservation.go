@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+func NewReservationRepository(ctx context.Context, client mysql.ClientContext) model.ReservationRepository {
+	return &reservationRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type reservationRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *reservationRepository) Add(reservation *model.Reservation) error {
+	_, err := r.client.ExecContext(r.ctx,
+		`INSERT INTO stock_reservation (order_id, product_id, variant_id, quantity, status, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		reservation.OrderID,
+		reservation.ProductID,
+		reservation.VariantID,
+		reservation.Quantity,
+		reservation.Status,
+		reservation.CreatedAt,
+		reservation.ExpiresAt,
+	)
+	var mysqlErr *gomysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntryErrorCode {
+		return errors.WithStack(model.ErrReservationExists)
+	}
+	return errors.WithStack(err)
+}
+
+func (r *reservationRepository) Update(reservation *model.Reservation) error {
+	_, err := r.client.ExecContext(r.ctx,
+		`UPDATE stock_reservation SET status = ? WHERE order_id = ? AND product_id = ? AND variant_id = ?`,
+		reservation.Status,
+		reservation.OrderID,
+		reservation.ProductID,
+		reservation.VariantID,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *reservationRepository) FindByOrder(orderID uuid.UUID) ([]*model.Reservation, error) {
+	var reservations []sqlxReservation
+	err := r.client.SelectContext(
+		r.ctx,
+		&reservations,
+		`SELECT order_id, product_id, variant_id, quantity, status, created_at, expires_at FROM stock_reservation WHERE order_id = ? ORDER BY product_id, variant_id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*model.Reservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		result = append(result, &model.Reservation{
+			OrderID:   reservation.OrderID,
+			ProductID: reservation.ProductID,
+			VariantID: reservation.VariantID,
+			Quantity:  reservation.Quantity,
+			Status:    model.ReservationStatus(reservation.Status),
+			CreatedAt: reservation.CreatedAt,
+			ExpiresAt: reservation.ExpiresAt,
+		})
+	}
+	return result, nil
+}
+
+func (r *reservationRepository) Delete(orderID, productID, variantID uuid.UUID) error {
+	_, err := r.client.ExecContext(r.ctx,
+		`DELETE FROM stock_reservation WHERE order_id = ? AND product_id = ? AND variant_id = ?`,
+		orderID,
+		productID,
+		variantID,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *reservationRepository) FindExpiredOrders(before time.Time, limit int) ([]uuid.UUID, error) {
+	var orderIDs []uuid.UUID
+	err := r.client.SelectContext(
+		r.ctx,
+		&orderIDs,
+		`SELECT DISTINCT order_id FROM stock_reservation WHERE status = ? AND expires_at < ? ORDER BY order_id LIMIT ?`,
+		model.ReservationActive,
+		before,
+		limit,
+	)
+	return orderIDs, errors.WithStack(err)
+}
+
+type sqlxReservation struct {
+	OrderID   uuid.UUID `db:"order_id"`
+	ProductID uuid.UUID `db:"product_id"`
+	VariantID uuid.UUID `db:"variant_id"`
+	Quantity  int       `db:"quantity"`
+	Status    int       `db:"status"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+const variantColumns = `variant_id, product_id, sku, attributes, price, stock, created_at, updated_at, deleted_at`
+
+func NewVariantRepository(ctx context.Context, client mysql.ClientContext) model.VariantRepository {
+	return &variantRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type variantRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (v *variantRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (v *variantRepository) Store(variant *model.Variant) error {
+	attributes, err := json.Marshal(attributesToSQLX(variant.Attributes))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var exists bool
+	err = v.client.GetContext(v.ctx, &exists, `SELECT EXISTS(SELECT 1 FROM product_variant WHERE variant_id = ?)`, variant.ID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// INSERT ... ON DUPLICATE KEY UPDATE is not used since it would update variant owning the same sku
+	if !exists {
+		_, err = v.client.ExecContext(v.ctx,
+			`
+		INSERT INTO product_variant (variant_id, product_id, sku, attributes, price, stock, created_at, updated_at, deleted_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			variant.ID,
+			variant.ProductID,
+			variant.SKU,
+			attributes,
+			toSQLNull(variant.Price),
+			variant.Stock,
+			variant.CreatedAt,
+			variant.UpdatedAt,
+			toSQLNull(variant.DeletedAt),
+		)
+	} else {
+		_, err = v.client.ExecContext(v.ctx,
+			`UPDATE product_variant SET sku = ?, attributes = ?, price = ?, stock = ?, updated_at = ?, deleted_at = ? WHERE variant_id = ?`,
+			variant.SKU,
+			attributes,
+			toSQLNull(variant.Price),
+			variant.Stock,
+			variant.UpdatedAt,
+			toSQLNull(variant.DeletedAt),
+			variant.ID,
+		)
+	}
+	return errors.WithStack(variantError(err))
+}
+
+func (v *variantRepository) Find(id uuid.UUID) (*model.Variant, error) {
+	return v.findOne(`SELECT `+variantColumns+` FROM product_variant WHERE variant_id = ? AND deleted_at IS NULL`, id)
+}
+
+func (v *variantRepository) FindForUpdate(id uuid.UUID) (*model.Variant, error) {
+	return v.findOne(`SELECT `+variantColumns+` FROM product_variant WHERE variant_id = ? AND deleted_at IS NULL FOR UPDATE`, id)
+}
+
+func (v *variantRepository) FindBySKU(sku string) (*model.Variant, error) {
+	return v.findOne(`SELECT `+variantColumns+` FROM product_variant WHERE active_sku = ?`, sku)
+}
+
+func (v *variantRepository) FindByProduct(productID uuid.UUID) ([]*model.Variant, error) {
+	var variants []sqlxVariant
+	err := v.client.SelectContext(
+		v.ctx,
+		&variants,
+		`SELECT `+variantColumns+` FROM product_variant WHERE product_id = ? AND deleted_at IS NULL ORDER BY sku`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*model.Variant, 0, len(variants))
+	for _, variant := range variants {
+		m, err := variant.toModel()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (v *variantRepository) Delete(id uuid.UUID) error {
+	currentTime := time.Now()
+	res, err := v.client.ExecContext(v.ctx,
+		`UPDATE product_variant SET deleted_at = ?, updated_at = ? WHERE variant_id = ? AND deleted_at IS NULL`,
+		currentTime,
+		currentTime,
+		id,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrVariantNotFound)
+	}
+	return nil
+}
+
+func (v *variantRepository) findOne(query string, args ...interface{}) (*model.Variant, error) {
+	var variant sqlxVariant
+	err := v.client.GetContext(v.ctx, &variant, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrVariantNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return variant.toModel()
+}
+
+func variantError(err error) error {
+	var mysqlErr *gomysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntryErrorCode {
+		return model.ErrVariantSKUExists
+	}
+	return err
+}
+
+type sqlxAttribute struct {
+	Name  string `json:"name"`
+	Type  int    `json:"type"`
+	Value string `json:"value"`
+}
+
+func attributesToSQLX(attributes []model.Attribute) []sqlxAttribute {
+	result := make([]sqlxAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		result = append(result, sqlxAttribute{
+			Name:  attribute.Name,
+			Type:  int(attribute.Type),
+			Value: attribute.Value,
+		})
+	}
+	return result
+}
+
+type sqlxVariant struct {
+	VariantID  uuid.UUID           `db:"variant_id"`
+	ProductID  uuid.UUID           `db:"product_id"`
+	SKU        string              `db:"sku"`
+	Attributes []byte              `db:"attributes"`
+	Price      sql.Null[float64]   `db:"price"`
+	Stock      int                 `db:"stock"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+	DeletedAt  sql.Null[time.Time] `db:"deleted_at"`
+}
+
+func (v sqlxVariant) toModel() (*model.Variant, error) {
+	var attributes []sqlxAttribute
+	if err := json.Unmarshal(v.Attributes, &attributes); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse attributes of variant %s", v.VariantID)
+	}
+	variant := &model.Variant{
+		ID:         v.VariantID,
+		ProductID:  v.ProductID,
+		SKU:        v.SKU,
+		Attributes: make([]model.Attribute, 0, len(attributes)),
+		Price:      fromSQLNull(v.Price),
+		Stock:      v.Stock,
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+		DeletedAt:  fromSQLNull(v.DeletedAt),
+	}
+	for _, attribute := range attributes {
+		variant.Attributes = append(variant.Attributes, model.Attribute{
+			Name:  attribute.Name,
+			Type:  model.AttributeType(attribute.Type),
+			Value: attribute.Value,
+		})
+	}
+	return variant, nil
+}
@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+
+	"product/pkg/application/service"
+	"product/pkg/domain/model"
+	"product/pkg/infrastructure/mysql/repository"
+)
+
+func NewRepositoryProvider(client mysql.ClientContext) service.RepositoryProvider {
+	return &repositoryProvider{client: client}
+}
+
+type repositoryProvider struct {
+	client mysql.ClientContext
+}
+
+func (r *repositoryProvider) ProductRepository(ctx context.Context) model.ProductRepository {
+	return repository.NewProductRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) PriceHistoryRepository(ctx context.Context) model.PriceHistoryRepository {
+	return repository.NewPriceHistoryRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ScheduledPriceChangeRepository(ctx context.Context) model.ScheduledPriceChangeRepository {
+	return repository.NewScheduledPriceChangeRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) CategoryRepository(ctx context.Context) model.CategoryRepository {
+	return repository.NewCategoryRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) VariantRepository(ctx context.Context) model.VariantRepository {
+	return repository.NewVariantRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ReservationRepository(ctx context.Context) model.ReservationRepository {
+	return repository.NewReservationRepository(ctx, r.client)
+}
@@ -0,0 +1,116 @@
+package productfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+const (
+	productIDColumn = "product_id"
+	nameColumn      = "name"
+	priceColumn     = "price"
+	stockColumn     = "stock"
+)
+
+// csvReader takes columns by names from the header, unknown columns are ignored
+type csvReader struct {
+	reader      *csv.Reader
+	nameIndex   int
+	priceIndex  int
+	columnCount int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv header")
+	}
+	c := &csvReader{
+		reader:      reader,
+		nameIndex:   -1,
+		priceIndex:  -1,
+		columnCount: len(header),
+	}
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case nameColumn:
+			c.nameIndex = i
+		case priceColumn:
+			c.priceIndex = i
+		}
+	}
+	if c.nameIndex < 0 || c.priceIndex < 0 {
+		return nil, errors.Errorf("csv header must have %q and %q columns", nameColumn, priceColumn)
+	}
+	return c, nil
+}
+
+func (c *csvReader) Read() (Row, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return Row{}, err
+		}
+		return Row{}, errors.Wrap(err, "failed to read csv record")
+	}
+	line, _ := c.reader.FieldPos(0)
+
+	if len(record) != c.columnCount {
+		return Row{}, &RowError{
+			Line: line,
+			Err:  errors.Errorf("expected %d columns, got %d", c.columnCount, len(record)),
+		}
+	}
+	price, err := strconv.ParseFloat(strings.TrimSpace(record[c.priceIndex]), 64)
+	// ParseFloat accepts NaN and Inf, which are not prices
+	if err != nil || math.IsNaN(price) || math.IsInf(price, 0) {
+		return Row{}, &RowError{
+			Line: line,
+			Err:  fmt.Errorf("%w: %q is not a number", model.ErrProductPriceInvalid, record[c.priceIndex]),
+		}
+	}
+	return Row{
+		Line:  line,
+		Name:  strings.TrimSpace(record[c.nameIndex]),
+		Price: price,
+	}, nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{productIDColumn, nameColumn, priceColumn, stockColumn})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (c *csvWriter) Write(product *model.Product) error {
+	return errors.WithStack(c.writer.Write([]string{
+		product.ID.String(),
+		product.Name,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		strconv.Itoa(product.Stock),
+	}))
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return errors.WithStack(c.writer.Error())
+}
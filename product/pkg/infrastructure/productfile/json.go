@@ -0,0 +1,111 @@
+package productfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+type jsonProduct struct {
+	ProductID string   `json:"productID,omitempty"`
+	Name      string   `json:"name"`
+	Price     *float64 `json:"price"`
+	Stock     *int     `json:"stock,omitempty"`
+}
+
+// jsonReader reads array of products element by element, so the whole file is not loaded into memory
+type jsonReader struct {
+	decoder *json.Decoder
+	index   int
+}
+
+func newJSONReader(r io.Reader) (*jsonReader, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read json array")
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("json file must contain array of products")
+	}
+	return &jsonReader{decoder: decoder}, nil
+}
+
+func (j *jsonReader) Read() (Row, error) {
+	if !j.decoder.More() {
+		if _, err := j.decoder.Token(); err != nil {
+			return Row{}, errors.Wrap(err, "failed to read json array end")
+		}
+		return Row{}, io.EOF
+	}
+
+	j.index++
+	var product jsonProduct
+	err := j.decoder.Decode(&product)
+	if err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return Row{}, errors.Wrap(err, "failed to read json array element")
+		}
+		// decoder has consumed the whole element, so reading can go on
+		if typeErr.Field == priceColumn {
+			err = fmt.Errorf("%w: %v", model.ErrProductPriceInvalid, err)
+		}
+		return Row{}, &RowError{Line: j.index, Err: err}
+	}
+	if product.Price == nil {
+		return Row{}, &RowError{
+			Line: j.index,
+			Err:  fmt.Errorf("%w: price is missing", model.ErrProductPriceInvalid),
+		}
+	}
+	return Row{
+		Line:  j.index,
+		Name:  product.Name,
+		Price: *product.Price,
+	}, nil
+}
+
+type jsonWriter struct {
+	writer  io.Writer
+	encoder *json.Encoder
+	count   int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{
+		writer:  w,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (j *jsonWriter) Write(product *model.Product) error {
+	separator := ","
+	if j.count == 0 {
+		separator = "["
+	}
+	if _, err := io.WriteString(j.writer, separator); err != nil {
+		return errors.WithStack(err)
+	}
+	j.count++
+
+	return errors.WithStack(j.encoder.Encode(jsonProduct{
+		ProductID: product.ID.String(),
+		Name:      product.Name,
+		Price:     &product.Price,
+		Stock:     &product.Stock,
+	}))
+}
+
+func (j *jsonWriter) Close() error {
+	end := "]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.writer, end)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,86 @@
+package productfile
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"product/pkg/domain/model"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+)
+
+// ParseFormat takes format by its name, empty name means format is taken from path extension
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	switch format := Format(strings.ToLower(name)); format {
+	case CSV, JSON:
+		return format, nil
+	default:
+		return "", errors.Errorf("unknown file format %q", name)
+	}
+}
+
+// Row is a product to import, Line is a line of CSV record or a 1-based index of JSON array element
+type Row struct {
+	Line  int
+	Name  string
+	Price float64
+}
+
+// RowError is returned for malformed row, reading can go on with the next row
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type Reader interface {
+	// Read returns io.EOF after the last row
+	Read() (Row, error)
+}
+
+func NewReader(format Format, r io.Reader) (Reader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r)
+	case JSON:
+		return newJSONReader(r)
+	default:
+		return nil, errors.Errorf("unknown file format %q", format)
+	}
+}
+
+type Writer interface {
+	Write(product *model.Product) error
+	// Close flushes written products, it does not close underlying writer
+	Close() error
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w)
+	case JSON:
+		return newJSONWriter(w), nil
+	default:
+		return nil, errors.Errorf("unknown file format %q", format)
+	}
+}
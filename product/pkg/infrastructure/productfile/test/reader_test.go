@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"product/pkg/domain/model"
+	"product/pkg/infrastructure/productfile"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readResult is a row or a line of malformed row read from file
+type readResult struct {
+	row          productfile.Row
+	errLine      int
+	invalidPrice bool
+}
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		format productfile.Format
+		input  string
+		want   []readResult
+	}{
+		{
+			name:   "CSV_TakesColumnsByHeader",
+			format: productfile.CSV,
+			input:  "stock,Price, name \n5,100,Keyboard\n7, 12.5 , Mouse \n",
+			want: []readResult{
+				{row: productfile.Row{Line: 2, Name: "Keyboard", Price: 100}},
+				{row: productfile.Row{Line: 3, Name: "Mouse", Price: 12.5}},
+			},
+		},
+		{
+			name:   "CSV_ReportsMalformedRowsAndGoesOn",
+			format: productfile.CSV,
+			input:  "name,price\nKeyboard\nMouse,cheap\nMonitor,300\n",
+			want: []readResult{
+				{errLine: 2},
+				{errLine: 3, invalidPrice: true},
+				{row: productfile.Row{Line: 4, Name: "Monitor", Price: 300}},
+			},
+		},
+		{
+			name:   "CSV_RejectsNonFinitePrices",
+			format: productfile.CSV,
+			input:  "name,price\nKeyboard,NaN\nMouse,Inf\nMonitor,-Inf\nHeadset,50\n",
+			want: []readResult{
+				{errLine: 2, invalidPrice: true},
+				{errLine: 3, invalidPrice: true},
+				{errLine: 4, invalidPrice: true},
+				{row: productfile.Row{Line: 5, Name: "Headset", Price: 50}},
+			},
+		},
+		{
+			name:   "JSON_ReadsArrayElements",
+			format: productfile.JSON,
+			input:  `[{"name":"Keyboard","price":100},{"productID":"ignored","name":"Mouse","price":12.5,"stock":3}]`,
+			want: []readResult{
+				{row: productfile.Row{Line: 1, Name: "Keyboard", Price: 100}},
+				{row: productfile.Row{Line: 2, Name: "Mouse", Price: 12.5}},
+			},
+		},
+		{
+			name:   "JSON_ReportsMalformedElementsAndGoesOn",
+			format: productfile.JSON,
+			input:  `[{"name":"Keyboard"},{"name":"Mouse","price":"cheap"},{"name":1,"price":5},{"name":"Monitor","price":300}]`,
+			want: []readResult{
+				{errLine: 1, invalidPrice: true},
+				{errLine: 2, invalidPrice: true},
+				{errLine: 3},
+				{row: productfile.Row{Line: 4, Name: "Monitor", Price: 300}},
+			},
+		},
+		{
+			name:   "JSON_RejectsNaNPrice",
+			format: productfile.JSON,
+			input:  `[{"name":"Keyboard","price":"NaN"},{"name":"Mouse","price":50}]`,
+			want: []readResult{
+				{errLine: 1, invalidPrice: true},
+				{row: productfile.Row{Line: 2, Name: "Mouse", Price: 50}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := productfile.NewReader(tt.format, strings.NewReader(tt.input))
+			require.NoError(t, err)
+
+			var got []readResult
+			for {
+				row, err := reader.Read()
+				if err == io.EOF {
+					break
+				}
+				var rowErr *productfile.RowError
+				if errors.As(err, &rowErr) {
+					got = append(got, readResult{
+						errLine:      rowErr.Line,
+						invalidPrice: errors.Is(err, model.ErrProductPriceInvalid),
+					})
+					continue
+				}
+				require.NoError(t, err)
+				got = append(got, readResult{row: row})
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReader_FailsOnInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		format productfile.Format
+		input  string
+	}{
+		{name: "CSV_Empty", format: productfile.CSV, input: ""},
+		{name: "CSV_MissingPriceColumn", format: productfile.CSV, input: "name,stock\nKeyboard,5\n"},
+		{name: "CSV_MissingNameColumn", format: productfile.CSV, input: "price\n100\n"},
+		{name: "JSON_NotArray", format: productfile.JSON, input: `{"name":"Keyboard","price":100}`},
+		{name: "JSON_Empty", format: productfile.JSON, input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := productfile.NewReader(tt.format, strings.NewReader(tt.input))
+			require.Error(t, err)
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	"product/pkg/application/query"
+	"product/pkg/domain/model"
 )
 
 type errorSet map[error]struct{}
@@ -18,13 +21,49 @@ func newErrorSet(errs ...error) errorSet {
 }
 
 func (s errorSet) Has(err error) bool {
-	_, ok := s[err]
-	return ok
+	if _, ok := s[err]; ok {
+		return true
+	}
+	// errors wrapped with fmt.Errorf are not unwrapped by errors.Cause
+	for e := range s {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	model.ErrProductNameRequired,
+	model.ErrProductPriceInvalid,
+	model.ErrProductStockInvalid,
+	model.ErrReservationQuantityInvalid,
+	model.ErrCategoryNameRequired,
+	model.ErrPriceChangeEffectiveAtPast,
+	model.ErrVariantSKURequired,
+	model.ErrVariantAttributesInvalid,
+	query.ErrInvalidCursor,
+)
+
+var notFoundErrorCodes = newErrorSet(
+	model.ErrProductNotFound,
+	model.ErrReservationNotFound,
+	model.ErrCategoryNotFound,
+	model.ErrPriceChangeNotFound,
+	model.ErrVariantNotFound,
+	query.ErrPriceNotFound,
+)
+
+var alreadyExistsErrorCodes = newErrorSet(
+	model.ErrProductNameExists,
+	model.ErrVariantSKUExists,
+	model.ErrVariantAttributesExist,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var failedPreconditionErrorCodes = newErrorSet(
+	model.ErrInsufficientStock,
+	model.ErrCategoryCycle,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +82,10 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isAlreadyExistsError(cause):
+		return codes.AlreadyExists
+	case isFailedPreconditionError(cause):
+		return codes.FailedPrecondition
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -68,6 +111,7 @@ func isWarnLevel(err error) bool {
 		codes.PermissionDenied,
 		codes.InvalidArgument,
 		codes.NotFound,
+		codes.AlreadyExists,
 		codes.FailedPrecondition,
 		codes.Unauthenticated:
 		return true
@@ -84,6 +128,14 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isAlreadyExistsError(cause error) bool {
+	return alreadyExistsErrorCodes.Has(cause)
+}
+
+func isFailedPreconditionError(cause error) bool {
+	return failedPreconditionErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
@@ -2,15 +2,52 @@ package transport
 
 import (
 	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	api "product/api/server/productinternal"
+	"product/pkg/application/query"
+	"product/pkg/application/service"
+	"product/pkg/domain/model"
+	domainservice "product/pkg/domain/service"
 )
 
-func NewInternalAPI() api.ProductInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(
+	productService service.ProductService,
+	productQueryService query.ProductQueryService,
+	stockService service.StockService,
+	categoryService service.CategoryService,
+	categoryQueryService query.CategoryQueryService,
+	priceService service.PriceService,
+	priceQueryService query.PriceQueryService,
+	variantService service.VariantService,
+) api.ProductInternalServiceServer {
+	return &internalAPI{
+		productService:       productService,
+		productQueryService:  productQueryService,
+		stockService:         stockService,
+		categoryService:      categoryService,
+		categoryQueryService: categoryQueryService,
+		priceService:         priceService,
+		priceQueryService:    priceQueryService,
+		variantService:       variantService,
+	}
 }
 
 type internalAPI struct {
+	productService       service.ProductService
+	productQueryService  query.ProductQueryService
+	stockService         service.StockService
+	categoryService      service.CategoryService
+	categoryQueryService query.CategoryQueryService
+	priceService         service.PriceService
+	priceQueryService    query.PriceQueryService
+	variantService       service.VariantService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +55,615 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateProduct(ctx context.Context, request *api.CreateProductRequest) (*api.CreateProductResponse, error) {
+	product, err := i.productService.CreateProduct(ctx, request.Name, request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateProductResponse{
+		Product: productToAPI(product),
+	}, nil
+}
+
+func (i *internalAPI) UpdateProduct(ctx context.Context, request *api.UpdateProductRequest) (*api.UpdateProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := i.productService.UpdateProduct(ctx, productID, request.Name, request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateProductResponse{
+		Product: productToAPI(product),
+	}, nil
+}
+
+func (i *internalAPI) DeleteProduct(ctx context.Context, request *api.DeleteProductRequest) (*api.DeleteProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.productService.DeleteProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteProductResponse{}, nil
+}
+
+func (i *internalAPI) GetProduct(ctx context.Context, request *api.GetProductRequest) (*api.GetProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := i.productService.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetProductResponse{
+		Product: productToAPI(product),
+	}, nil
+}
+
+func (i *internalAPI) ListProducts(ctx context.Context, request *api.ListProductsRequest) (*api.ListProductsResponse, error) {
+	spec, err := listProductsSpecFromAPI(request)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := i.productQueryService.ListProducts(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*api.Product, 0, len(page.Products))
+	for _, product := range page.Products {
+		products = append(products, productToAPI(product))
+	}
+	return &api.ListProductsResponse{
+		Products:      products,
+		NextPageToken: page.NextCursor,
+	}, nil
+}
+
+var importActionToAPI = map[domainservice.UpsertAction]api.ImportAction{
+	domainservice.UpsertCreated:   api.ImportAction_CREATED,
+	domainservice.UpsertUpdated:   api.ImportAction_UPDATED,
+	domainservice.UpsertUnchanged: api.ImportAction_UNCHANGED,
+}
+
+func (i *internalAPI) ImportProducts(stream grpc.BidiStreamingServer[api.ImportProductsRequest, api.ImportProductsResponse]) error {
+	ctx := stream.Context()
+	session := i.productService.NewImportSession()
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		response := &api.ImportProductsResponse{Line: request.Line}
+		product, action, err := session.Import(ctx, request.Name, request.Price, request.DryRun)
+		switch {
+		case err == nil:
+			response.Action = importActionToAPI[action]
+			// product created by dry run does not exist
+			if product != nil {
+				response.ProductID = product.ID.String()
+			}
+		case service.IsImportRowError(err):
+			response.Action = api.ImportAction_FAILED
+			response.Error = err.Error()
+		default:
+			return err
+		}
+
+		if err = stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *internalAPI) ExportProducts(request *api.ExportProductsRequest, stream grpc.ServerStreamingServer[api.ExportProductsResponse]) error {
+	spec := query.ListProductsSpec{IncludeDeleted: request.IncludeDeleted}
+	return query.ForEachProduct(stream.Context(), i.productQueryService, spec, func(product *model.Product) error {
+		return stream.Send(&api.ExportProductsResponse{
+			Product: productToAPI(product),
+		})
+	})
+}
+
+var productSortFieldFromAPI = map[api.ProductSortField]query.ProductSortField{
+	api.ProductSortField_NAME:       query.SortByName,
+	api.ProductSortField_PRICE:      query.SortByPrice,
+	api.ProductSortField_CREATED_AT: query.SortByCreatedAt,
+}
+
+func listProductsSpecFromAPI(request *api.ListProductsRequest) (query.ListProductsSpec, error) {
+	sortBy, ok := productSortFieldFromAPI[request.SortBy]
+	if !ok {
+		return query.ListProductsSpec{}, status.Errorf(codes.InvalidArgument, "invalid sort field %d", request.SortBy)
+	}
+	if request.PageSize < 0 {
+		return query.ListProductsSpec{}, status.Errorf(codes.InvalidArgument, "invalid page size %d", request.PageSize)
+	}
+	categoryID, err := parseOptionalUUID(request.CategoryID)
+	if err != nil {
+		return query.ListProductsSpec{}, err
+	}
+	return query.ListProductsSpec{
+		NamePrefix:     request.NamePrefix,
+		NameContains:   request.NameContains,
+		MinPrice:       request.MinPrice,
+		MaxPrice:       request.MaxPrice,
+		IncludeDeleted: request.IncludeDeleted,
+		CategoryID:     categoryID,
+		SortBy:         sortBy,
+		Descending:     request.Descending,
+		Cursor:         request.PageToken,
+		PageSize:       int(request.PageSize),
+	}, nil
+}
+
+func (i *internalAPI) SetStock(ctx context.Context, request *api.SetStockRequest) (*api.SetStockResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := i.stockService.SetStock(ctx, productID, int(request.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetStockResponse{
+		Product: productToAPI(product),
+	}, nil
+}
+
+func (i *internalAPI) SetVariantStock(ctx context.Context, request *api.SetVariantStockRequest) (*api.SetVariantStockResponse, error) {
+	variantID, err := parseUUID(request.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := i.stockService.SetVariantStock(ctx, variantID, int(request.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	apiVariant, err := i.variantToAPI(ctx, variant)
+	if err != nil {
+		return nil, err
+	}
+	return &api.SetVariantStockResponse{
+		Variant: apiVariant,
+	}, nil
+}
+
+func (i *internalAPI) ReserveStock(ctx context.Context, request *api.ReserveStockRequest) (*api.ReserveStockResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domainservice.ReservationItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		productID, err := parseUUID(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		variantID, err := parseOptionalUUID(item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		reservationItem := domainservice.ReservationItem{
+			ProductID: productID,
+			Quantity:  int(item.Quantity),
+		}
+		if variantID != nil {
+			reservationItem.VariantID = *variantID
+		}
+		items = append(items, reservationItem)
+	}
+
+	err = i.stockService.ReserveStock(ctx, orderID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ReserveStockResponse{}, nil
+}
+
+func (i *internalAPI) ReleaseStock(ctx context.Context, request *api.ReleaseStockRequest) (*api.ReleaseStockResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.stockService.ReleaseStock(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ReleaseStockResponse{}, nil
+}
+
+func (i *internalAPI) CommitStock(ctx context.Context, request *api.CommitStockRequest) (*api.CommitStockResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.stockService.CommitStock(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CommitStockResponse{}, nil
+}
+
+func (i *internalAPI) CreateCategory(ctx context.Context, request *api.CreateCategoryRequest) (*api.CreateCategoryResponse, error) {
+	parentID, err := parseOptionalUUID(request.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := i.categoryService.CreateCategory(ctx, request.Name, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateCategoryResponse{
+		Category: categoryToAPI(category),
+	}, nil
+}
+
+func (i *internalAPI) UpdateCategory(ctx context.Context, request *api.UpdateCategoryRequest) (*api.UpdateCategoryResponse, error) {
+	categoryID, err := parseUUID(request.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalUUID(request.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := i.categoryService.UpdateCategory(ctx, categoryID, request.Name, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateCategoryResponse{
+		Category: categoryToAPI(category),
+	}, nil
+}
+
+func (i *internalAPI) ListCategories(ctx context.Context, _ *api.ListCategoriesRequest) (*api.ListCategoriesResponse, error) {
+	categories, err := i.categoryQueryService.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.Category, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, categoryToAPI(category))
+	}
+	return &api.ListCategoriesResponse{
+		Categories: result,
+	}, nil
+}
+
+func (i *internalAPI) AssignProductCategory(ctx context.Context, request *api.AssignProductCategoryRequest) (*api.AssignProductCategoryResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := parseUUID(request.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.categoryService.AssignProduct(ctx, categoryID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AssignProductCategoryResponse{}, nil
+}
+
+func (i *internalAPI) UnassignProductCategory(ctx context.Context, request *api.UnassignProductCategoryRequest) (*api.UnassignProductCategoryResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := parseUUID(request.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.categoryService.UnassignProduct(ctx, categoryID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UnassignProductCategoryResponse{}, nil
+}
+
+func (i *internalAPI) CreateVariant(ctx context.Context, request *api.CreateVariantRequest) (*api.CreateVariantResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := i.variantService.CreateVariant(ctx, productID, request.Sku, attributesFromAPI(request.Attributes), request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVariant, err := i.variantToAPI(ctx, variant)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CreateVariantResponse{
+		Variant: apiVariant,
+	}, nil
+}
+
+func (i *internalAPI) UpdateVariant(ctx context.Context, request *api.UpdateVariantRequest) (*api.UpdateVariantResponse, error) {
+	variantID, err := parseUUID(request.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := i.variantService.UpdateVariant(ctx, variantID, request.Sku, attributesFromAPI(request.Attributes), request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVariant, err := i.variantToAPI(ctx, variant)
+	if err != nil {
+		return nil, err
+	}
+	return &api.UpdateVariantResponse{
+		Variant: apiVariant,
+	}, nil
+}
+
+func (i *internalAPI) DeleteVariant(ctx context.Context, request *api.DeleteVariantRequest) (*api.DeleteVariantResponse, error) {
+	variantID, err := parseUUID(request.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.variantService.DeleteVariant(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteVariantResponse{}, nil
+}
+
+func (i *internalAPI) GetVariant(ctx context.Context, request *api.GetVariantRequest) (*api.GetVariantResponse, error) {
+	variantID, err := parseUUID(request.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := i.variantService.GetVariant(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVariant, err := i.variantToAPI(ctx, variant)
+	if err != nil {
+		return nil, err
+	}
+	return &api.GetVariantResponse{
+		Variant: apiVariant,
+	}, nil
+}
+
+func (i *internalAPI) ListVariants(ctx context.Context, request *api.ListVariantsRequest) (*api.ListVariantsResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := i.variantService.ListVariants(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	product, err := i.productService.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.Variant, 0, len(variants))
+	for _, variant := range variants {
+		result = append(result, variantWithProductToAPI(variant, product))
+	}
+	return &api.ListVariantsResponse{
+		Variants: result,
+	}, nil
+}
+
+// variantToAPI takes product of variant for effective price and title
+func (i *internalAPI) variantToAPI(ctx context.Context, variant *model.Variant) (*api.Variant, error) {
+	product, err := i.productService.GetProduct(ctx, variant.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	return variantWithProductToAPI(variant, product), nil
+}
+
+func variantWithProductToAPI(variant *model.Variant, product *model.Product) *api.Variant {
+	attributes := make([]*api.Attribute, 0, len(variant.Attributes))
+	for _, attribute := range variant.Attributes {
+		attributes = append(attributes, &api.Attribute{
+			Name:  attribute.Name,
+			Type:  api.AttributeType(attribute.Type),
+			Value: attribute.Value,
+		})
+	}
+	return &api.Variant{
+		VariantID:       variant.ID.String(),
+		ProductID:       variant.ProductID.String(),
+		Sku:             variant.SKU,
+		Attributes:      attributes,
+		Price:           variant.EffectivePrice(*product),
+		PriceOverridden: variant.Price != nil,
+		Title:           variant.Title(*product),
+		Stock:           int32(variant.Stock),
+		CreatedAt:       variant.CreatedAt.Unix(),
+		UpdatedAt:       variant.UpdatedAt.Unix(),
+	}
+}
+
+func attributesFromAPI(attributes []*api.Attribute) []model.Attribute {
+	result := make([]model.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		result = append(result, model.Attribute{
+			Name:  attribute.Name,
+			Type:  model.AttributeType(attribute.Type),
+			Value: attribute.Value,
+		})
+	}
+	return result
+}
+
+func (i *internalAPI) GetPriceAt(ctx context.Context, request *api.GetPriceAtRequest) (*api.GetPriceAtResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := i.priceQueryService.GetPriceAt(ctx, productID, time.Unix(request.At, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetPriceAtResponse{
+		Price:         entry.Price,
+		EffectiveFrom: entry.EffectiveFrom.Unix(),
+	}, nil
+}
+
+func (i *internalAPI) SchedulePriceChange(ctx context.Context, request *api.SchedulePriceChangeRequest) (*api.SchedulePriceChangeResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := i.priceService.SchedulePriceChange(ctx, productID, request.Price, time.Unix(request.EffectiveAt, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SchedulePriceChangeResponse{
+		PriceChange: scheduledPriceChangeToAPI(change),
+	}, nil
+}
+
+func (i *internalAPI) CancelPriceChange(ctx context.Context, request *api.CancelPriceChangeRequest) (*api.CancelPriceChangeResponse, error) {
+	changeID, err := parseUUID(request.PriceChangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.priceService.CancelPriceChange(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CancelPriceChangeResponse{}, nil
+}
+
+func (i *internalAPI) ListScheduledPriceChanges(ctx context.Context, request *api.ListScheduledPriceChangesRequest) (*api.ListScheduledPriceChangesResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := i.priceQueryService.ListScheduledPriceChanges(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.ScheduledPriceChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, scheduledPriceChangeToAPI(change))
+	}
+	return &api.ListScheduledPriceChangesResponse{
+		PriceChanges: result,
+	}, nil
+}
+
+func scheduledPriceChangeToAPI(change *model.ScheduledPriceChange) *api.ScheduledPriceChange {
+	return &api.ScheduledPriceChange{
+		PriceChangeID: change.ID.String(),
+		ProductID:     change.ProductID.String(),
+		Price:         change.Price,
+		EffectiveAt:   change.EffectiveAt.Unix(),
+		CreatedAt:     change.CreatedAt.Unix(),
+	}
+}
+
+func categoryToAPI(category *model.Category) *api.Category {
+	var parentID string
+	if category.ParentID != nil {
+		parentID = category.ParentID.String()
+	}
+	return &api.Category{
+		CategoryID: category.ID.String(),
+		Name:       category.Name,
+		ParentID:   parentID,
+		CreatedAt:  category.CreatedAt.Unix(),
+		UpdatedAt:  category.UpdatedAt.Unix(),
+	}
+}
+
+func productToAPI(product *model.Product) *api.Product {
+	var deletedAt int64
+	if product.DeletedAt != nil {
+		deletedAt = product.DeletedAt.Unix()
+	}
+	return &api.Product{
+		ProductID: product.ID.String(),
+		Name:      product.Name,
+		Price:     product.Price,
+		CreatedAt: product.CreatedAt.Unix(),
+		UpdatedAt: product.UpdatedAt.Unix(),
+		DeletedAt: deletedAt,
+		Stock:     int32(product.Stock),
+	}
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q", value)
+	}
+	return id, nil
+}
+
+// parseOptionalUUID returns nil for empty value
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := parseUUID(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
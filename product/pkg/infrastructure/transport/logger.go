@@ -50,3 +50,28 @@ func MakeLoggerServerInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor
 		return resp, err
 	}
 }
+
+func MakeLoggerStreamServerInterceptor(logger *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+
+		err = handler(srv, stream)
+
+		fields := log.Fields{
+			"duration": time.Since(start).String(),
+			"route":    info.FullMethod,
+		}
+
+		loggerWithFields := logger.WithFields(fields)
+		if err == nil {
+			loggerWithFields.Infof("stream finished")
+		} else {
+			if isWarnLevel(err) {
+				loggerWithFields.Warnf("stream failed: %v", err)
+			} else {
+				loggerWithFields.Errorf("stream failed: %v", err)
+			}
+		}
+		return err
+	}
+}